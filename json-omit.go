@@ -0,0 +1,325 @@
+package sqljson
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// zeroer is implemented by values that know whether they are zero, as every
+// sqljson type does, reporting NULL.
+type zeroer interface {
+	IsZero() bool
+}
+
+// MarshalOmitNull marshals v to JSON like json.Marshal, but leaves out
+// struct fields tagged omitempty or omitzero whose value is NULL, an unset
+// Optional, or otherwise zero according to its IsZero method. Values
+// without an IsZero method follow the encoding/json rules. It is meant
+// for encoders which do not support the omitzero tag option themselves.
+// Structs, pointers, slices, arrays and string-keyed maps are walked; every
+// other value is marshaled by encoding/json. Fields promoted through
+// unexported embedded structs are left out.
+func MarshalOmitNull(v interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	err := marshalOmitNull(buf, reflect.ValueOf(v))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func marshalOmitNull(buf *bytes.Buffer, v reflect.Value) error {
+	if !v.IsValid() {
+		buf.WriteString("null")
+		return nil
+	}
+	t := v.Type()
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) ||
+		(v.CanAddr() && (reflect.PtrTo(t).Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType))) {
+		return marshalJSONTo(buf, v.Interface())
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		return marshalOmitNull(buf, v.Elem())
+	case reflect.Struct:
+		return marshalStructOmitNull(buf, v)
+	case reflect.Slice:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			return marshalJSONTo(buf, v.Interface())
+		}
+		return marshalElemsOmitNull(buf, v)
+	case reflect.Array:
+		return marshalElemsOmitNull(buf, v)
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String || t.Key().Implements(textMarshalerType) {
+			return marshalJSONTo(buf, v.Interface())
+		}
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		return marshalMapOmitNull(buf, v)
+	}
+	return marshalJSONTo(buf, v.Interface())
+}
+
+func marshalJSONTo(buf *bytes.Buffer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+func marshalElemsOmitNull(buf *bytes.Buffer, v reflect.Value) error {
+	buf.WriteByte('[')
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		err := marshalOmitNull(buf, v.Index(i))
+		if err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+func marshalMapOmitNull(buf *bytes.Buffer, v reflect.Value) error {
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		err := marshalJSONTo(buf, key.String())
+		if err != nil {
+			return err
+		}
+		buf.WriteByte(':')
+		err = marshalOmitNull(buf, v.MapIndex(key))
+		if err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// jsonField is a struct field marshaled to JSON.
+type jsonField struct {
+	name      string
+	index     []int
+	tagged    bool
+	omitEmpty bool
+	omitZero  bool
+	quoted    bool
+}
+
+// jsonFields returns the fields of struct type t marshaled to JSON, in
+// declaration order. Untagged anonymous struct fields are flattened into
+// their parent. As in encoding/json, of the fields sharing a name only the
+// least nested one is kept, or at the same depth the only tagged one;
+// others are hidden.
+func jsonFields(t reflect.Type) []jsonField {
+	all := walkJSONFields(t)
+	byName := map[string][]jsonField{}
+	for _, f := range all {
+		byName[f.name] = append(byName[f.name], f)
+	}
+	fields := []jsonField{}
+	for _, f := range all {
+		dominant, ok := dominantJSONField(byName[f.name])
+		if ok && reflect.DeepEqual(dominant.index, f.index) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// dominantJSONField returns the field encoding/json marshals among fields
+// sharing a name, or false when none of them dominates.
+func dominantJSONField(fields []jsonField) (jsonField, bool) {
+	depth := len(fields[0].index)
+	for _, f := range fields {
+		if len(f.index) < depth {
+			depth = len(f.index)
+		}
+	}
+	var shallow, tagged []jsonField
+	for _, f := range fields {
+		if len(f.index) == depth {
+			shallow = append(shallow, f)
+			if f.tagged {
+				tagged = append(tagged, f)
+			}
+		}
+	}
+	if len(shallow) == 1 {
+		return shallow[0], true
+	}
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+	return jsonField{}, false
+}
+
+// walkJSONFields returns every field of struct type t marshaled to JSON,
+// including the fields hidden by others of the same name.
+func walkJSONFields(t reflect.Type) []jsonField {
+	fields := []jsonField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if comma := strings.Index(tag, ","); comma >= 0 {
+			name, opts = tag[:comma], tag[comma+1:]
+		}
+		embedded := field.Type
+		if embedded.Kind() == reflect.Ptr {
+			embedded = embedded.Elem()
+		}
+		if field.Anonymous && name == "" && embedded.Kind() == reflect.Struct {
+			for _, f := range walkJSONFields(embedded) {
+				f.index = append([]int{i}, f.index...)
+				fields = append(fields, f)
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		f := jsonField{name: name, index: field.Index, tagged: name != ""}
+		if name == "" {
+			f.name = field.Name
+		}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty":
+				f.omitEmpty = true
+			case "omitzero":
+				f.omitZero = true
+			case "string":
+				f.quoted = true
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but reports false instead
+// of panicking on a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// isEmptyJSONValue reports whether v is left out by omitempty, or by omitzero
+// when zero is true. Values with an IsZero method are empty when it reports
+// true. Otherwise omitempty follows encoding/json, which never leaves out
+// structs, while omitzero leaves out any zero value.
+func isEmptyJSONValue(v reflect.Value, zero bool) bool {
+	if z, ok := v.Interface().(zeroer); ok {
+		if v.Kind() != reflect.Ptr || !v.IsNil() {
+			return z.IsZero()
+		}
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	if zero {
+		return v.IsZero()
+	}
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	}
+	return false
+}
+
+// isQuotableKind reports whether the string tag option applies to kind.
+func isQuotableKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func marshalStructOmitNull(buf *bytes.Buffer, v reflect.Value) error {
+	buf.WriteByte('{')
+	first := true
+	for _, f := range jsonFields(v.Type()) {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok || !fv.CanInterface() || (f.omitEmpty && isEmptyJSONValue(fv, false)) || (f.omitZero && isEmptyJSONValue(fv, true)) {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		err := marshalJSONTo(buf, f.name)
+		if err != nil {
+			return err
+		}
+		buf.WriteByte(':')
+		if f.quoted && isQuotableKind(fv.Kind()) {
+			var data []byte
+			data, err = json.Marshal(fv.Interface())
+			if err == nil {
+				err = marshalJSONTo(buf, string(data))
+			}
+		} else {
+			err = marshalOmitNull(buf, fv)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
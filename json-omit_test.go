@@ -0,0 +1,213 @@
+package sqljson_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rhaseven7h/sqljson"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type omitAll struct {
+	String    sqljson.NullString              `json:"string,omitzero"`
+	Bool      sqljson.NullBool                `json:"bool,omitzero"`
+	Int64     sqljson.NullInt64               `json:"int64,omitzero"`
+	Float64   sqljson.NullFloat64             `json:"float64,omitzero"`
+	Int32     sqljson.NullInt32               `json:"int32,omitzero"`
+	Int16     sqljson.NullInt16               `json:"int16,omitzero"`
+	Byte      sqljson.NullByte                `json:"byte,omitzero"`
+	Uint64    sqljson.NullUint64              `json:"uint64,omitzero"`
+	Time      sqljson.NullTime                `json:"time,omitzero"`
+	Decimal   sqljson.NullDecimal             `json:"decimal,omitzero"`
+	Raw       sqljson.NullRawMessage          `json:"raw,omitzero"`
+	JSON      sqljson.NullJSON[[]int]         `json:"json,omitzero"`
+	Generic   sqljson.Null[string]            `json:"generic,omitzero"`
+	Optional  sqljson.Optional[int64]         `json:"optional,omitzero"`
+	Lenient   sqljson.LenientNullInt64        `json:"lenient,omitzero"`
+	Empty     sqljson.EmptyNullString         `json:"empty,omitzero"`
+	Blank     sqljson.BlankNullString         `json:"blank,omitzero"`
+	Kept      sqljson.NullString              `json:"kept"`
+	Generics  []sqljson.Null[int]             `json:"generics,omitempty"`
+	Nested    *omitNested                     `json:"nested,omitempty"`
+	Documents map[string]sqljson.NullDecimal  `json:"documents,omitempty"`
+	Others    map[string]sqljson.Null[string] `json:"-"`
+}
+
+type omitNested struct {
+	Name sqljson.NullString `json:"name,omitempty"`
+	Age  sqljson.NullInt64  `json:"age,omitempty"`
+}
+
+func allNonNull() omitAll {
+	return omitAll{
+		String:   sqljson.NewNullString("a"),
+		Bool:     sqljson.NewNullBool(false),
+		Int64:    sqljson.NewNullInt64(0),
+		Float64:  sqljson.NewNullFloat64(1.5),
+		Int32:    sqljson.NewNullInt32(2),
+		Int16:    sqljson.NewNullInt16(3),
+		Byte:     sqljson.NewNullByte(4),
+		Uint64:   sqljson.NewNullUint64(5),
+		Time:     sqljson.NewNullTime(time.Date(2017, time.May, 1, 10, 20, 30, 0, time.UTC)),
+		Decimal:  sqljson.NewNullDecimal("6.00"),
+		Raw:      sqljson.NewNullRawMessage(json.RawMessage(`{}`)),
+		JSON:     sqljson.NewNullJSON([]int{7}),
+		Generic:  sqljson.NewNull("b"),
+		Optional: sqljson.OptionalFrom[int64](nil),
+		Lenient:  sqljson.LenientNullInt64{NullInt64: sqljson.NewNullInt64(8)},
+		Empty:    sqljson.EmptyNullString{NullString: sqljson.NewNullString("c")},
+		Blank:    sqljson.BlankNullString{NullString: sqljson.NewNullString("d")},
+	}
+}
+
+const allNonNullJSON = `{"string":"a","bool":false,"int64":0,"float64":1.5,"int32":2,"int16":3,"byte":4,"uint64":5,` +
+	`"time":"2017-05-01T10:20:30Z","decimal":6.00,"raw":{},"json":[7],"generic":"b","optional":null,"lenient":8,` +
+	`"empty":"c","blank":"d","kept":null}`
+
+func TestIsZero(t *testing.T) {
+	Convey("Given a struct of NULL sqljson values tagged omitzero", t, func() {
+		v := omitAll{
+			Empty: sqljson.EmptyNullString{NullString: sqljson.NewNullString("")},
+			Blank: sqljson.BlankNullString{NullString: sqljson.NewNullString(" ")},
+		}
+		Convey("When I marshal it with encoding/json", func() {
+			b, err := json.Marshal(v)
+			Convey("Then only the untagged field should be left", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"kept":null}`)
+			})
+		})
+	})
+	Convey("Given a struct of non-NULL sqljson values tagged omitzero, holding zero values", t, func() {
+		v := allNonNull()
+		Convey("When I marshal it with encoding/json", func() {
+			b, err := json.Marshal(v)
+			Convey("Then every field, and the set NULL Optional, should be kept", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, allNonNullJSON)
+			})
+		})
+	})
+}
+
+func TestMarshalOmitNull(t *testing.T) {
+	Convey("Given a struct of NULL sqljson values tagged omitzero and omitempty", t, func() {
+		v := &omitAll{
+			Generics:  []sqljson.Null[int]{{}, sqljson.NewNull(1)},
+			Nested:    &omitNested{Age: sqljson.NewNullInt64(40)},
+			Documents: map[string]sqljson.NullDecimal{"b": {}, "a": sqljson.NewNullDecimal("1.0")},
+		}
+		Convey("When I marshal it with sqljson.MarshalOmitNull", func() {
+			b, err := sqljson.MarshalOmitNull(v)
+			Convey("Then NULL fields should be left out at every level", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"kept":null,"generics":[null,1],"nested":{"age":40},"documents":{"a":1.0,"b":null}}`)
+			})
+		})
+	})
+	Convey("Given a struct of non-NULL sqljson values", t, func() {
+		v := allNonNull()
+		Convey("When I marshal it with sqljson.MarshalOmitNull", func() {
+			b, err := sqljson.MarshalOmitNull(v)
+			Convey("Then I should get the same JSON as encoding/json", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, allNonNullJSON)
+			})
+		})
+	})
+	Convey("Given a struct with embedded structs, plain omitempty fields and the string option", t, func() {
+		type Base struct {
+			ID sqljson.NullInt64 `json:"id,omitempty"`
+		}
+		type Audit struct {
+			By string `json:"by,omitempty"`
+		}
+		v := struct {
+			Base
+			*Audit
+			Count  int    `json:"count,omitempty"`
+			Total  int    `json:"total,string"`
+			Label  string `json:",omitempty"`
+			hidden string
+		}{Base: Base{ID: sqljson.NewNullInt64(1)}, Total: 3, hidden: "x"}
+		Convey("When I marshal it with sqljson.MarshalOmitNull", func() {
+			b, err := sqljson.MarshalOmitNull(v)
+			Convey("Then it should follow the encoding/json field rules", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"id":1,"total":"3"}`)
+			})
+		})
+	})
+	Convey("Given a struct with zero plain structs and arrays tagged omitempty and omitzero", t, func() {
+		type Point struct {
+			X int `json:"x"`
+		}
+		v := struct {
+			Origin    Point  `json:"origin,omitempty"`
+			Corner    Point  `json:"corner,omitzero"`
+			Flags     [2]int `json:"flags,omitempty"`
+			Mask      [2]int `json:"mask,omitzero"`
+			Count     int    `json:"count,omitempty"`
+			Remaining int    `json:"remaining,omitzero"`
+		}{}
+		Convey("When I marshal it with sqljson.MarshalOmitNull and json.Marshal", func() {
+			b, err := sqljson.MarshalOmitNull(v)
+			expected, errExpected := json.Marshal(v)
+			Convey("Then omitempty should keep the struct and array, and omitzero leave them out, as in encoding/json", func() {
+				So(err, ShouldBeNil)
+				So(errExpected, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"origin":{"x":0},"flags":[0,0]}`)
+				So(string(b), ShouldEqual, string(expected))
+			})
+		})
+	})
+	Convey("Given a struct with fields shadowing fields of its embedded structs", t, func() {
+		type Base struct {
+			ID   sqljson.NullInt64  `json:"id"`
+			Name sqljson.NullString `json:"name"`
+			Note sqljson.NullString
+		}
+		type Tagged struct {
+			Note sqljson.NullString `json:"Note"`
+		}
+		type Untagged struct {
+			Kind sqljson.NullString
+		}
+		type Other struct {
+			Kind sqljson.NullString
+		}
+		v := struct {
+			Base
+			Tagged
+			Untagged
+			Other
+			Name sqljson.NullString `json:"name"`
+		}{
+			Base:     Base{ID: sqljson.NewNullInt64(1), Name: sqljson.NewNullString("inner"), Note: sqljson.NewNullString("base")},
+			Tagged:   Tagged{Note: sqljson.NewNullString("tagged")},
+			Untagged: Untagged{Kind: sqljson.NewNullString("a")},
+			Other:    Other{Kind: sqljson.NewNullString("b")},
+			Name:     sqljson.NewNullString("outer"),
+		}
+		Convey("When I marshal it with sqljson.MarshalOmitNull", func() {
+			b, err := sqljson.MarshalOmitNull(v)
+			Convey("Then only the dominant fields should be written, as with encoding/json", func() {
+				So(err, ShouldBeNil)
+				expected, _ := json.Marshal(v)
+				So(string(b), ShouldEqual, string(expected))
+				So(string(b), ShouldEqual, `{"id":1,"Note":"tagged","name":"outer"}`)
+			})
+		})
+	})
+	Convey("Given a value holding an invalid decimal", t, func() {
+		v := omitAll{Decimal: sqljson.NewNullDecimal("1,5")}
+		Convey("When I marshal it with sqljson.MarshalOmitNull", func() {
+			_, err := sqljson.MarshalOmitNull(v)
+			Convey("Then I should get the marshaling error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "invalid decimal")
+			})
+		})
+	})
+}
@@ -0,0 +1,103 @@
+package sqljson
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// columnField is a struct field mapped to a database column.
+type columnField struct {
+	column string
+	index  []int
+}
+
+// columnFields returns the fields of struct type t mapped to columns, in
+// declaration order. Anonymous struct fields are flattened into their parent,
+// unless they are tagged or scan themselves.
+func columnFields(t reflect.Type) []columnField {
+	fields := []columnField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("db") == "" &&
+			!reflect.PtrTo(field.Type).Implements(scannerType) {
+			for _, embedded := range columnFields(field.Type) {
+				embedded.index = append([]int{i}, embedded.index...)
+				fields = append(fields, embedded)
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		column, ok := columnName(field)
+		if !ok {
+			continue
+		}
+		fields = append(fields, columnField{column: column, index: field.Index})
+	}
+	return fields
+}
+
+// columnName returns the column a struct field maps to: its db tag, else the
+// name in its json tag, else its name in snake_case. ok is false for fields
+// tagged db:"-".
+func columnName(field reflect.StructField) (name string, ok bool) {
+	if tag := field.Tag.Get("db"); tag != "" {
+		name = strings.Split(tag, ",")[0]
+		if name == "-" {
+			return "", false
+		}
+		if name != "" {
+			return name, true
+		}
+	}
+	if tag := field.Tag.Get("json"); tag != "" {
+		name = strings.Split(tag, ",")[0]
+		if name != "" && name != "-" {
+			return name, true
+		}
+	}
+	return snakeCase(field.Name), true
+}
+
+// snakeCase converts a Go identifier such as "ContactEmail" or "UserID" into
+// "contact_email" or "user_id".
+func snakeCase(name string) string {
+	runes := []rune(name)
+	b := strings.Builder{}
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// structValue dereferences src down to a struct value.
+func structValue(src interface{}) (reflect.Value, error) {
+	value := reflect.ValueOf(src)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return reflect.Value{}, fmt.Errorf("sqljson: expected a struct, got a nil %T", src)
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("sqljson: expected a struct, got %T", src)
+	}
+	return value, nil
+}
@@ -0,0 +1,132 @@
+package sqljson
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// UnknownColumnError is returned when a result column maps to no field of
+// the destination struct.
+type UnknownColumnError struct {
+	Column string
+	Type   reflect.Type
+}
+
+func (e *UnknownColumnError) Error() string {
+	return fmt.Sprintf("sqljson: column %q has no matching field in %s", e.Column, e.Type)
+}
+
+// MissingColumnError is returned when fields of the destination struct have
+// no matching result column.
+type MissingColumnError struct {
+	Columns []string
+	Type    reflect.Type
+}
+
+func (e *MissingColumnError) Error() string {
+	return fmt.Sprintf("sqljson: %s has no result column for %s", e.Type, strings.Join(e.Columns, ", "))
+}
+
+// scanIndexes returns the index of the struct field each result column is
+// scanned into.
+func scanIndexes(t reflect.Type, columns []string) ([][]int, error) {
+	fields := columnFields(t)
+	indexes := make([][]int, len(columns))
+	scanned := map[string]bool{}
+	for i, column := range columns {
+		for _, field := range fields {
+			if field.column == column {
+				indexes[i] = field.index
+				break
+			}
+		}
+		if indexes[i] == nil {
+			return nil, &UnknownColumnError{Column: column, Type: t}
+		}
+		scanned[column] = true
+	}
+	missing := []string{}
+	for _, field := range fields {
+		if !scanned[field.column] {
+			missing = append(missing, field.column)
+			scanned[field.column] = true
+		}
+	}
+	if len(missing) > 0 {
+		return nil, &MissingColumnError{Columns: missing, Type: t}
+	}
+	return indexes, nil
+}
+
+func scanInto(rows *sql.Rows, value reflect.Value, indexes [][]int) error {
+	targets := make([]interface{}, len(indexes))
+	for i, index := range indexes {
+		targets[i] = value.FieldByIndex(index).Addr().Interface()
+	}
+	return rows.Scan(targets...)
+}
+
+// ScanStruct scans the current row of rows into dest, a pointer to struct.
+// Columns map to fields by their db tag, else their json tag name, else
+// their name in snake_case. Every column must map to a field, and every
+// field to a column, or an *UnknownColumnError or *MissingColumnError is
+// returned.
+func ScanStruct(rows *sql.Rows, dest interface{}) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("sqljson: expected a pointer to struct, got %T", dest)
+	}
+	value = value.Elem()
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	indexes, err := scanIndexes(value.Type(), columns)
+	if err != nil {
+		return err
+	}
+	return scanInto(rows, value, indexes)
+}
+
+// ScanAll scans every row of rows into dest, a pointer to a slice of
+// structs or of pointers to structs, and closes rows. Columns map to fields
+// as in ScanStruct.
+func ScanAll(rows *sql.Rows, dest interface{}) error {
+	defer rows.Close()
+	slice := reflect.ValueOf(dest)
+	if slice.Kind() != reflect.Ptr || slice.IsNil() || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("sqljson: expected a pointer to slice, got %T", dest)
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("sqljson: expected a pointer to slice of structs, got %T", dest)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	indexes, err := scanIndexes(structType, columns)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		elem := reflect.New(structType)
+		err = scanInto(rows, elem.Elem(), indexes)
+		if err != nil {
+			return err
+		}
+		if elemType.Kind() == reflect.Ptr {
+			slice.Set(reflect.Append(slice, elem))
+		} else {
+			slice.Set(reflect.Append(slice, elem.Elem()))
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,169 @@
+package sqljson_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type scanAudit struct {
+	CreatedBy sqljson.NullString
+}
+
+type scanSupplier struct {
+	scanAudit
+	ID           int
+	ContactEmail sqljson.NullString  `json:"email"`
+	IsAdmin      sqljson.NullBool    `db:"admin"`
+	Followers    sqljson.NullInt64   `json:"-"`
+	BankBalance  sqljson.NullDecimal `db:"balance"`
+	Ignored      string              `db:"-"`
+}
+
+var scanColumns = []string{"id", "email", "admin", "followers", "balance", "created_by"}
+
+func TestScanStruct(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	Convey("Given a sql mock returning a row with valid and null values", t, func() {
+		mock.
+			ExpectQuery(`SELECT (.+) FROM suppliers`).
+			WillReturnRows(sqlmock.NewRows(scanColumns).AddRow(10, "gmedina@ooyala.com", nil, 100, "123.45", "admin"))
+		Convey("When I query it and scan it with sqljson.ScanStruct", func() {
+			supplier := &scanSupplier{}
+			rows, dbErr := db.Query(`SELECT * FROM suppliers`)
+			So(dbErr, ShouldBeNil)
+			defer rows.Close()
+			So(rows.Next(), ShouldBeTrue)
+			err := sqljson.ScanStruct(rows, supplier)
+			Convey("Then every column should be scanned into its field", func() {
+				So(err, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+				So(supplier.ID, ShouldEqual, 10)
+				So(supplier.ContactEmail.String, ShouldEqual, "gmedina@ooyala.com")
+				So(supplier.IsAdmin.Valid, ShouldBeFalse)
+				So(supplier.Followers.Int64, ShouldEqual, 100)
+				So(supplier.BankBalance.Decimal, ShouldEqual, "123.45")
+				So(supplier.CreatedBy.String, ShouldEqual, "admin")
+			})
+		})
+	})
+	Convey("Given a sql mock returning a column without a matching field", t, func() {
+		mock.
+			ExpectQuery(`SELECT (.+) FROM suppliers`).
+			WillReturnRows(sqlmock.NewRows(append(scanColumns, "phone")).AddRow(10, nil, nil, nil, nil, nil, nil))
+		Convey("When I scan it with sqljson.ScanStruct", func() {
+			rows, _ := db.Query(`SELECT * FROM suppliers`)
+			defer rows.Close()
+			rows.Next()
+			err := sqljson.ScanStruct(rows, &scanSupplier{})
+			Convey("Then I should get an UnknownColumnError", func() {
+				var unknown *sqljson.UnknownColumnError
+				So(errors.As(err, &unknown), ShouldBeTrue)
+				So(unknown.Column, ShouldEqual, "phone")
+				So(err.Error(), ShouldContainSubstring, `column "phone" has no matching field in sqljson_test.scanSupplier`)
+			})
+		})
+	})
+	Convey("Given a sql mock missing columns for some fields", t, func() {
+		mock.
+			ExpectQuery(`SELECT (.+) FROM suppliers`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "followers"}).AddRow(10, nil))
+		Convey("When I scan it with sqljson.ScanStruct", func() {
+			rows, _ := db.Query(`SELECT * FROM suppliers`)
+			defer rows.Close()
+			rows.Next()
+			err := sqljson.ScanStruct(rows, &scanSupplier{})
+			Convey("Then I should get a MissingColumnError", func() {
+				var missing *sqljson.MissingColumnError
+				So(errors.As(err, &missing), ShouldBeTrue)
+				So(missing.Columns, ShouldResemble, []string{"created_by", "email", "admin", "balance"})
+			})
+		})
+	})
+	Convey("Given a destination which is not a pointer to struct", t, func() {
+		Convey("When I scan into it with sqljson.ScanStruct", func() {
+			err := sqljson.ScanStruct(nil, scanSupplier{})
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "expected a pointer to struct")
+			})
+		})
+	})
+}
+
+func TestScanAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	Convey("Given a sql mock returning several rows", t, func() {
+		rows := func() *sqlmock.Rows {
+			return sqlmock.NewRows(scanColumns).
+				AddRow(10, "gmedina@ooyala.com", true, 100, "123.45", nil).
+				AddRow(11, nil, false, nil, nil, "admin")
+		}
+		Convey("When I scan them into a slice of structs with sqljson.ScanAll", func() {
+			mock.ExpectQuery(`SELECT (.+) FROM suppliers`).WillReturnRows(rows())
+			suppliers := []scanSupplier{}
+			dbRows, _ := db.Query(`SELECT * FROM suppliers`)
+			err := sqljson.ScanAll(dbRows, &suppliers)
+			Convey("Then I should get every row", func() {
+				So(err, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+				So(len(suppliers), ShouldEqual, 2)
+				So(suppliers[0].ID, ShouldEqual, 10)
+				So(suppliers[0].IsAdmin.Bool, ShouldBeTrue)
+				So(suppliers[1].ContactEmail.Valid, ShouldBeFalse)
+				So(suppliers[1].CreatedBy.String, ShouldEqual, "admin")
+			})
+		})
+		Convey("When I scan them into a slice of pointers with sqljson.ScanAll", func() {
+			mock.ExpectQuery(`SELECT (.+) FROM suppliers`).WillReturnRows(rows())
+			suppliers := []*scanSupplier{}
+			dbRows, _ := db.Query(`SELECT * FROM suppliers`)
+			err := sqljson.ScanAll(dbRows, &suppliers)
+			Convey("Then I should get every row", func() {
+				So(err, ShouldBeNil)
+				So(len(suppliers), ShouldEqual, 2)
+				So(suppliers[1].ID, ShouldEqual, 11)
+			})
+		})
+	})
+	Convey("Given a sql mock returning a value which cannot be scanned", t, func() {
+		mock.
+			ExpectQuery(`SELECT (.+) FROM suppliers`).
+			WillReturnRows(sqlmock.NewRows(scanColumns).AddRow(10, nil, nil, nil, "1,5", nil))
+		Convey("When I scan it with sqljson.ScanAll", func() {
+			suppliers := []scanSupplier{}
+			dbRows, _ := db.Query(`SELECT * FROM suppliers`)
+			err := sqljson.ScanAll(dbRows, &suppliers)
+			Convey("Then I should get the scan error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "invalid decimal")
+				So(suppliers, ShouldBeEmpty)
+			})
+		})
+	})
+	Convey("Given a destination which is not a pointer to a slice of structs", t, func() {
+		mock.ExpectQuery(`SELECT (.+) FROM suppliers`).WillReturnRows(sqlmock.NewRows(scanColumns))
+		Convey("When I scan into it with sqljson.ScanAll", func() {
+			dbRows, _ := db.Query(`SELECT * FROM suppliers`)
+			err := sqljson.ScanAll(dbRows, &[]int{})
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "expected a pointer to slice of structs")
+			})
+		})
+	})
+}
@@ -0,0 +1,147 @@
+package sqljson
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Placeholder is a style of SQL bind parameter.
+type Placeholder int
+
+const (
+	// QuestionPlaceholder binds parameters as ?, as MySQL and SQLite do.
+	QuestionPlaceholder Placeholder = iota
+	// DollarPlaceholder binds parameters as $1, $2..., as PostgreSQL does.
+	DollarPlaceholder
+	// NamedPlaceholder binds parameters as :column, passing sql.NamedArg
+	// arguments.
+	NamedPlaceholder
+)
+
+// Statement builds INSERT and UPDATE statements from a struct, or pointer
+// to struct, whose fields map to columns as in UpdateAssignments. Arguments
+// are the fields themselves, so sqljson types are written through their
+// driver.Valuer.
+type Statement struct {
+	// Placeholder is the bind parameter style.
+	Placeholder Placeholder
+	// SkipNull leaves out fields that are NULL or nil, and Optional fields
+	// that were not set. A set Optional is kept even when NULL, as it means
+	// "clear this column".
+	SkipNull bool
+}
+
+// isNullArg reports whether value is written as NULL.
+func isNullArg(value interface{}) bool {
+	if v := reflect.ValueOf(value); !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return true
+	}
+	if opt, ok := value.(optional); ok {
+		return !opt.IsSet()
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		return err == nil && v == nil
+	}
+	return false
+}
+
+// columnValues returns the columns of src and their field values, keeping
+// only the fields for which keep returns true.
+func columnValues(src interface{}, keep func(value interface{}) bool) (columns []string, values []interface{}, err error) {
+	value, err := structValue(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	columns = []string{}
+	values = []interface{}{}
+	for _, field := range columnFields(value.Type()) {
+		fieldValue := value.FieldByIndex(field.index).Interface()
+		if keep(fieldValue) {
+			columns = append(columns, field.column)
+			values = append(values, fieldValue)
+		}
+	}
+	return columns, values, nil
+}
+
+// Columns returns the columns of src and their arguments.
+func (s Statement) Columns(src interface{}) (columns []string, args []interface{}, err error) {
+	return columnValues(src, func(value interface{}) bool {
+		return !s.SkipNull || !isNullArg(value)
+	})
+}
+
+// placeholder returns the bind parameter for the i-th argument, counting
+// from 0, bound to column.
+func (s Statement) placeholder(i int, column string) string {
+	switch s.Placeholder {
+	case DollarPlaceholder:
+		return "$" + strconv.Itoa(i+1)
+	case NamedPlaceholder:
+		return ":" + column
+	}
+	return "?"
+}
+
+// bind returns the placeholders of columns, and args ready to be passed to
+// the driver.
+func (s Statement) bind(columns []string, args []interface{}) ([]string, []interface{}) {
+	placeholders := make([]string, len(columns))
+	for i, column := range columns {
+		placeholders[i] = s.placeholder(i, column)
+		if s.Placeholder == NamedPlaceholder {
+			args[i] = sql.Named(column, args[i])
+		}
+	}
+	return placeholders, args
+}
+
+// Insert returns an "INSERT INTO table (columns) VALUES (placeholders)"
+// statement for src and its arguments.
+func (s Statement) Insert(table string, src interface{}) (query string, args []interface{}, err error) {
+	columns, args, err := s.Columns(src)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(columns) == 0 {
+		return "", nil, fmt.Errorf("sqljson: no columns to insert from %T", src)
+	}
+	placeholders, args := s.bind(columns, args)
+	query = "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(placeholders, ", ") + ")"
+	return query, args, nil
+}
+
+// Assignments returns the "column = placeholder" assignments of src and
+// their arguments.
+func (s Statement) Assignments(src interface{}) (assignments []string, args []interface{}, err error) {
+	columns, args, err := s.Columns(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	placeholders, args := s.bind(columns, args)
+	assignments = make([]string, len(columns))
+	for i, column := range columns {
+		assignments[i] = column + " = " + placeholders[i]
+	}
+	return assignments, args, nil
+}
+
+// Update returns an "UPDATE table SET assignments" statement for src and
+// its arguments. The caller appends the WHERE clause and its arguments;
+// with DollarPlaceholder, its first placeholder is $n where n is
+// len(args)+1.
+func (s Statement) Update(table string, src interface{}) (query string, args []interface{}, err error) {
+	assignments, args, err := s.Assignments(src)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(assignments) == 0 {
+		return "", nil, fmt.Errorf("sqljson: no columns to update from %T", src)
+	}
+	return "UPDATE " + table + " SET " + strings.Join(assignments, ", "), args, nil
+}
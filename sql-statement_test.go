@@ -0,0 +1,134 @@
+package sqljson_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type statementSupplier struct {
+	ID           int                      `db:"-"`
+	ContactEmail sqljson.NullString       `json:"contact_email"`
+	IsAdmin      sqljson.NullBool         `db:"admin"`
+	Followers    sqljson.Optional[int64]  `json:"followers"`
+	BankBalance  sqljson.Optional[string] `json:"-"`
+	Nickname     *string
+}
+
+func TestStatementInsert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	supplier := &statementSupplier{
+		ContactEmail: sqljson.NewNullString("gmedina@ooyala.com"),
+		BankBalance:  sqljson.OptionalFrom[string](nil),
+	}
+	Convey("Given a struct with values, NULLs, a set NULL Optional and an unset Optional", t, func() {
+		Convey("When I build an INSERT with ? placeholders", func() {
+			query, args, err := sqljson.Statement{}.Insert("suppliers", supplier)
+			Convey("Then every column should be included, and the statement be executable", func() {
+				So(err, ShouldBeNil)
+				So(query, ShouldEqual, "INSERT INTO suppliers (contact_email, admin, followers, bank_balance, nickname) VALUES (?, ?, ?, ?, ?)")
+				mock.
+					ExpectExec(`INSERT INTO suppliers \(contact_email, admin, followers, bank_balance, nickname\) VALUES \(\?, \?, \?, \?, \?\)`).
+					WithArgs("gmedina@ooyala.com", nil, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				_, dbErr := db.Exec(query, args...)
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+			})
+		})
+		Convey("When I build an INSERT with $n placeholders, skipping NULLs", func() {
+			query, args, err := sqljson.Statement{Placeholder: sqljson.DollarPlaceholder, SkipNull: true}.Insert("suppliers", supplier)
+			Convey("Then only the values and the set Optional should be included", func() {
+				So(err, ShouldBeNil)
+				So(query, ShouldEqual, "INSERT INTO suppliers (contact_email, bank_balance) VALUES ($1, $2)")
+				So(len(args), ShouldEqual, 2)
+			})
+		})
+		Convey("When I build an INSERT with :name placeholders", func() {
+			query, args, err := sqljson.Statement{Placeholder: sqljson.NamedPlaceholder, SkipNull: true}.Insert("suppliers", *supplier)
+			Convey("Then the arguments should be named after their columns", func() {
+				So(err, ShouldBeNil)
+				So(query, ShouldEqual, "INSERT INTO suppliers (contact_email, bank_balance) VALUES (:contact_email, :bank_balance)")
+				So(args[0].(sql.NamedArg).Name, ShouldEqual, "contact_email")
+				So(args[0].(sql.NamedArg).Value, ShouldResemble, supplier.ContactEmail)
+				So(args[1].(sql.NamedArg).Name, ShouldEqual, "bank_balance")
+			})
+		})
+	})
+	Convey("Given a struct with only NULLs", t, func() {
+		Convey("When I build an INSERT skipping NULLs", func() {
+			_, _, err := sqljson.Statement{SkipNull: true}.Insert("suppliers", &statementSupplier{ID: 10})
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "no columns to insert")
+			})
+		})
+	})
+	Convey("Given something else than a struct", t, func() {
+		Convey("When I build an INSERT", func() {
+			_, _, err := sqljson.Statement{}.Insert("suppliers", 10)
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "expected a struct")
+			})
+		})
+	})
+}
+
+func TestStatementUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	nickname := "gabo"
+	supplier := &statementSupplier{
+		ID:        10,
+		IsAdmin:   sqljson.NewNullBool(true),
+		Followers: sqljson.OptionalFrom[int64](nil),
+		Nickname:  &nickname,
+	}
+	Convey("Given a struct with values, NULLs and Optionals", t, func() {
+		Convey("When I build an UPDATE with $n placeholders, skipping NULLs", func() {
+			query, args, err := sqljson.Statement{Placeholder: sqljson.DollarPlaceholder, SkipNull: true}.Update("suppliers", supplier)
+			Convey("Then the WHERE clause should be appendable, and the statement be executable", func() {
+				So(err, ShouldBeNil)
+				So(query, ShouldEqual, "UPDATE suppliers SET admin = $1, followers = $2, nickname = $3")
+				mock.
+					ExpectExec(`UPDATE suppliers SET admin = \$1, followers = \$2, nickname = \$3 WHERE id = \$4`).
+					WithArgs(true, nil, "gabo", 10).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				_, dbErr := db.Exec(query+" WHERE id = $4", append(args, supplier.ID)...)
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+			})
+		})
+		Convey("When I build the assignments with ? placeholders", func() {
+			assignments, args, err := sqljson.Statement{}.Assignments(supplier)
+			Convey("Then every column should be included", func() {
+				So(err, ShouldBeNil)
+				So(assignments, ShouldResemble, []string{"contact_email = ?", "admin = ?", "followers = ?", "bank_balance = ?", "nickname = ?"})
+				So(len(args), ShouldEqual, 5)
+			})
+		})
+	})
+	Convey("Given a struct with only NULLs", t, func() {
+		Convey("When I build an UPDATE skipping NULLs", func() {
+			_, _, err := sqljson.Statement{SkipNull: true}.Update("suppliers", &statementSupplier{})
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "no columns to update")
+			})
+		})
+	})
+}
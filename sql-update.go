@@ -0,0 +1,23 @@
+package sqljson
+
+// UpdateAssignments builds the assignment list of a partial SQL UPDATE from
+// src, a struct or pointer to struct. Only Optional fields that were set are
+// included, each as "column = ?" with the field itself as its argument, so
+// explicit nulls become NULL and absent fields are left untouched. Fields
+// map to columns by their db tag, else their json tag name, else their name
+// in snake_case. Use Statement for other placeholder styles, or to include
+// fields of other types.
+func UpdateAssignments(src interface{}) (assignments []string, args []interface{}, err error) {
+	columns, args, err := columnValues(src, func(value interface{}) bool {
+		opt, ok := value.(optional)
+		return ok && opt.IsSet()
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	assignments = make([]string, len(columns))
+	for i, column := range columns {
+		assignments[i] = column + " = ?"
+	}
+	return assignments, args, nil
+}
@@ -0,0 +1,73 @@
+package sqljson_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUpdateAssignments(t *testing.T) {
+	type patchSupplier struct {
+		ID           int
+		ContactEmail sqljson.Optional[string]  `json:"contact_email"`
+		IsAdmin      sqljson.Optional[bool]    `db:"admin" json:"is_admin"`
+		Followers    sqljson.Optional[int64]   `json:"followers"`
+		BankBalance  sqljson.Optional[float64] `json:"-"`
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	Convey("Given a PATCH body with a value, an explicit null and missing keys", t, func() {
+		patch := &patchSupplier{}
+		err := json.Unmarshal([]byte(`{"ID": 10, "contact_email": "gmedina@ooyala.com", "is_admin": null}`), patch)
+		So(err, ShouldBeNil)
+		Convey("When I build the update assignments", func() {
+			assignments, args, err := sqljson.UpdateAssignments(patch)
+			Convey("Then I should only get the present fields", func() {
+				So(err, ShouldBeNil)
+				So(assignments, ShouldResemble, []string{"contact_email = ?", "admin = ?"})
+				So(len(args), ShouldEqual, 2)
+			})
+			Convey("Then I should be able to execute them", func() {
+				mock.
+					ExpectExec(`UPDATE suppliers SET contact_email = \?, admin = \? WHERE id = \?`).
+					WithArgs("gmedina@ooyala.com", nil, 10).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				_, dbErr := db.Exec(
+					"UPDATE suppliers SET "+strings.Join(assignments, ", ")+" WHERE id = ?",
+					append(args, patch.ID)...,
+				)
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a struct with a set field without tags", t, func() {
+		patch := patchSupplier{}
+		_ = patch.BankBalance.UnmarshalJSON([]byte(`12.5`))
+		Convey("When I build the update assignments", func() {
+			assignments, _, err := sqljson.UpdateAssignments(patch)
+			Convey("Then the column should be the snake_case field name", func() {
+				So(err, ShouldBeNil)
+				So(assignments, ShouldResemble, []string{"bank_balance = ?"})
+			})
+		})
+	})
+	Convey("Given a value which is not a struct", t, func() {
+		Convey("When I build the update assignments", func() {
+			_, _, err := sqljson.UpdateAssignments(10)
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "expected a struct")
+			})
+		})
+	})
+}
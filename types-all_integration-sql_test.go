@@ -1,7 +1,11 @@
 package sqljson_test
 
 import (
+	"errors"
+	"math"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/rhaseven7h/sqljson"
 
@@ -144,3 +148,80 @@ func TestSQLIntegration(t *testing.T) {
 		})
 	})
 }
+
+func TestSQLIntegrationValues(t *testing.T) {
+	type modelSupplier struct {
+		ContactEmail sqljson.NullString
+		IsAdmin      sqljson.NullBool
+		Followers    sqljson.NullInt32
+		BankBalance  sqljson.NullFloat64
+		CreatedAt    sqljson.NullTime
+		Rating       sqljson.Null[float32]
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	insert := `INSERT INTO suppliers (contact_email, is_admin, followers, bank_balance, created_at, rating) VALUES (?, ?, ?, ?, ?, ?)`
+	createdAt := time.Date(2017, time.May, 1, 10, 20, 30, 123456789, time.FixedZone("UTC-5", -5*60*60))
+
+	Convey("Given a struct using sqljson.Null* - with valid values, and normalizers", t, func() {
+		sqljson.NormalizeString = strings.TrimSpace
+		sqljson.NormalizeTime = func(t time.Time) time.Time { return t.UTC().Truncate(time.Microsecond) }
+		Reset(func() {
+			sqljson.NormalizeString = nil
+			sqljson.NormalizeTime = nil
+		})
+		supplier := modelSupplier{
+			ContactEmail: sqljson.NewNullString("  gmedina@ooyala.com "),
+			IsAdmin:      sqljson.NewNullBool(true),
+			Followers:    sqljson.NewNullInt32(100),
+			BankBalance:  sqljson.NewNullFloat64(123.45),
+			CreatedAt:    sqljson.NewNullTime(createdAt),
+			Rating:       sqljson.NewNull(float32(4.5)),
+		}
+		Convey("When I insert it", func() {
+			mock.
+				ExpectExec(`INSERT INTO suppliers`).
+				WithArgs("gmedina@ooyala.com", true, int64(100), 123.45,
+					time.Date(2017, time.May, 1, 15, 20, 30, 123456000, time.UTC), float64(4.5)).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			_, dbErr := db.Exec(insert, supplier.ContactEmail, supplier.IsAdmin, supplier.Followers,
+				supplier.BankBalance, supplier.CreatedAt, supplier.Rating)
+			Convey("Then the driver should get the normalized values", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a struct using sqljson.Null* - with null values", t, func() {
+		supplier := modelSupplier{}
+		Convey("When I insert it", func() {
+			mock.
+				ExpectExec(`INSERT INTO suppliers`).
+				WithArgs(nil, nil, nil, nil, nil, nil).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			_, dbErr := db.Exec(insert, supplier.ContactEmail, supplier.IsAdmin, supplier.Followers,
+				supplier.BankBalance, supplier.CreatedAt, supplier.Rating)
+			Convey("Then the driver should get NULLs", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+			})
+		})
+	})
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		f := f
+		Convey("Given a struct using sqljson.NullFloat64 - with a non-finite value", t, func() {
+			supplier := modelSupplier{BankBalance: sqljson.NewNullFloat64(f)}
+			Convey("When I insert it", func() {
+				_, dbErr := db.Exec(insert, supplier.ContactEmail, supplier.IsAdmin, supplier.Followers,
+					supplier.BankBalance, supplier.CreatedAt, supplier.Rating)
+				Convey("Then I should get ErrNonFiniteFloat before reaching the driver", func() {
+					So(errors.Is(dbErr, sqljson.ErrNonFiniteFloat), ShouldBeTrue)
+					So(mock.ExpectationsWereMet(), ShouldBeNil)
+				})
+			})
+		})
+	}
+}
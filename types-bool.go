@@ -2,8 +2,11 @@ package sqljson
 
 import (
 	"database/sql"
-	"encoding/json"
+	"database/sql/driver"
+	"encoding/xml"
+	"fmt"
 	"reflect"
+	"strconv"
 )
 
 // NullBool //
@@ -14,43 +17,160 @@ type NullBool struct {
 // NullBoolValidateValuer //
 func NullBoolValidateValuer(field reflect.Value) interface{} {
 	if nullBool, ok := field.Interface().(NullBool); ok {
-		if nullBool.Valid {
-			return nullBool.Bool
-		}
+		return nullBool.ValidateValue()
 	}
 	return nil
 }
 
+func (ns NullBool) null() Null[bool] {
+	return newNull(ns.Bool, ns.Valid)
+}
+
+func nullBoolOf(n Null[bool]) NullBool {
+	return NullBool{NullBool: sql.NullBool{Bool: n.V, Valid: n.Valid}}
+}
+
+// NewNullBool //
+func NewNullBool(value bool) NullBool {
+	return nullBoolOf(NewNull(value))
+}
+
+// NullBoolFrom //
+func NullBoolFrom(value *bool) NullBool {
+	return nullBoolOf(NullFrom(value))
+}
+
+// NullBoolFromZero //
+func NullBoolFromZero(value bool) NullBool {
+	return nullBoolOf(NullFromZero(value))
+}
+
+// ValidateValue //
+func (ns NullBool) ValidateValue() interface{} {
+	return ns.null().ValidateValue()
+}
+
 // BoolPtrOrNil //
 func (ns NullBool) BoolPtrOrNil() *bool {
-	if ns.Valid {
-		s := ns.Bool
-		return &s
+	return ns.null().PtrOrNil()
+}
+
+// ValueOrZero //
+func (ns NullBool) ValueOrZero() bool {
+	return ns.null().ValueOrZero()
+}
+
+// ValueOr //
+func (ns NullBool) ValueOr(value bool) bool {
+	return ns.null().ValueOr(value)
+}
+
+// IsZero //
+func (ns NullBool) IsZero() bool {
+	return !ns.Valid
+}
+
+// Scan accepts booleans, the numbers 0 and 1, and texts such as "t"/"f",
+// "Y"/"N", "yes"/"no" or "on"/"off", in any case.
+func (ns *NullBool) Scan(value interface{}) error {
+	if value == nil {
+		ns.Bool, ns.Valid = false, false
+		return nil
+	}
+	v, err := scanBool(value, "NullBool")
+	if err != nil {
+		return err
 	}
+	ns.Bool, ns.Valid = v, true
 	return nil
 }
 
+// Value //
+func (ns NullBool) Value() (driver.Value, error) {
+	return ns.null().Value()
+}
+
 // MarshalJSON //
 func (ns NullBool) MarshalJSON() ([]byte, error) {
-	if ns.Valid {
-		return json.Marshal(ns.Bool)
-	}
-	return json.Marshal(nil)
+	return ns.null().MarshalJSON()
 }
 
 // UnmarshalJSON //
 func (ns *NullBool) UnmarshalJSON(data []byte) error {
-	value := new(bool)
-	err := json.Unmarshal(data, &value)
+	n := Null[bool]{}
+	err := n.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	ns.Bool = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXML //
+func (ns NullBool) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return ns.null().MarshalXML(e, start)
+}
+
+// UnmarshalXML //
+func (ns *NullBool) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	n := Null[bool]{}
+	err := n.UnmarshalXML(d, start)
+	if err != nil {
+		return err
+	}
+	ns.Bool = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXMLAttr //
+func (ns NullBool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return ns.null().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullBool) UnmarshalXMLAttr(attr xml.Attr) error {
+	n := Null[bool]{}
+	err := n.UnmarshalXMLAttr(attr)
 	if err != nil {
 		return err
 	}
-	if value != nil {
-		ns.Bool = *value
-		ns.Valid = true
-	} else {
+	ns.Bool = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// text returns the text of a non-NULL value.
+func (ns NullBool) text() string {
+	return strconv.FormatBool(ns.Bool)
+}
+
+// setText sets ns to the non-NULL value given as text.
+func (ns *NullBool) setText(text string) error {
+	value, err := strconv.ParseBool(text)
+	if err != nil {
+		return fmt.Errorf("sqljson: cannot unmarshal text %q into a bool", text)
+	}
+	ns.Bool = value
+	ns.Valid = true
+	return nil
+}
+
+// MarshalText //
+func (ns NullBool) MarshalText() ([]byte, error) {
+	if !ns.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(ns.text()), nil
+}
+
+// UnmarshalText //
+func (ns *NullBool) UnmarshalText(data []byte) error {
+	if isNullText(data) {
 		ns.Bool = false
 		ns.Valid = false
+		return nil
 	}
-	return nil
+	return ns.setText(string(data))
 }
@@ -204,3 +204,78 @@ func TestBoolUnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestBoolConstructors(t *testing.T) {
+	Convey("Given a bool value, a pointer to it, a nil pointer and the zero value", t, func() {
+		value := true
+		Convey("When I build sqljson.NullBool values from them", func() {
+			fromValue := sqljson.NewNullBool(value)
+			fromPtr := sqljson.NullBoolFrom(&value)
+			fromNil := sqljson.NullBoolFrom(nil)
+			fromZero := sqljson.NullBoolFromZero(false)
+			fromNonZero := sqljson.NullBoolFromZero(value)
+			Convey("Then only the nil pointer and the zero value should be NULL", func() {
+				So(fromValue.Valid, ShouldBeTrue)
+				So(fromValue.Bool, ShouldEqual, value)
+				So(fromPtr.Valid, ShouldBeTrue)
+				So(fromPtr.Bool, ShouldEqual, value)
+				So(fromNil.Valid, ShouldBeFalse)
+				So(fromZero.Valid, ShouldBeFalse)
+				So(fromNonZero.Valid, ShouldBeTrue)
+			})
+			Convey("Then ValueOrZero and ValueOr should fall back only when NULL", func() {
+				So(fromValue.ValueOrZero(), ShouldEqual, value)
+				So(fromValue.ValueOr(true), ShouldEqual, value)
+				So(fromNil.ValueOrZero(), ShouldEqual, false)
+				So(fromNil.ValueOr(true), ShouldEqual, true)
+			})
+		})
+	})
+}
+
+func TestBoolMarshalText(t *testing.T) {
+	Convey("Given a non-null and a null sqljson.NullBool value", t, func() {
+		valueIn := sqljson.NewNullBool(true)
+		null := sqljson.NullBool{}
+		Convey("When I marshal them as text", func() {
+			b, err := valueIn.MarshalText()
+			bNull, errNull := null.MarshalText()
+			Convey("Then I should get the value and sqljson.NullText", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "true")
+				So(errNull, ShouldBeNil)
+				So(string(bNull), ShouldEqual, sqljson.NullText)
+			})
+		})
+	})
+}
+
+func TestBoolUnmarshalText(t *testing.T) {
+	Convey("Given a sqljson.NullBool value pointer, and a text value", t, func() {
+		ns := &sqljson.NullBool{}
+		Convey("When I Unmarshal it using UnmarshalText", func() {
+			err := ns.UnmarshalText([]byte("true"))
+			Convey("Then I should get a not-Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeTrue)
+				So(ns.Bool, ShouldEqual, true)
+			})
+		})
+		Convey("When I Unmarshal sqljson.NullText using UnmarshalText", func() {
+			_ = ns.UnmarshalText([]byte("true"))
+			err := ns.UnmarshalText([]byte(sqljson.NullText))
+			Convey("Then I should get a Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+		Convey("When I Unmarshal an invalid text using UnmarshalText", func() {
+			err := ns.UnmarshalText([]byte("yes"))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "cannot unmarshal text")
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -0,0 +1,141 @@
+package sqljson
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/xml"
+	"math"
+	"reflect"
+)
+
+// NullByte //
+type NullByte struct {
+	sql.NullByte
+}
+
+// NullByteValidateValuer //
+func NullByteValidateValuer(field reflect.Value) interface{} {
+	if nullByte, ok := field.Interface().(NullByte); ok {
+		return nullByte.ValidateValue()
+	}
+	return nil
+}
+
+func (ns NullByte) null() Null[byte] {
+	return newNull(ns.Byte, ns.Valid)
+}
+
+func nullByteOf(n Null[byte]) NullByte {
+	return NullByte{NullByte: sql.NullByte{Byte: n.V, Valid: n.Valid}}
+}
+
+// NewNullByte //
+func NewNullByte(value byte) NullByte {
+	return nullByteOf(NewNull(value))
+}
+
+// NullByteFrom //
+func NullByteFrom(value *byte) NullByte {
+	return nullByteOf(NullFrom(value))
+}
+
+// NullByteFromZero //
+func NullByteFromZero(value byte) NullByte {
+	return nullByteOf(NullFromZero(value))
+}
+
+// ValidateValue //
+func (ns NullByte) ValidateValue() interface{} {
+	return ns.null().ValidateValue()
+}
+
+// BytePtrOrNil //
+func (ns NullByte) BytePtrOrNil() *byte {
+	return ns.null().PtrOrNil()
+}
+
+// ValueOrZero //
+func (ns NullByte) ValueOrZero() byte {
+	return ns.null().ValueOrZero()
+}
+
+// ValueOr //
+func (ns NullByte) ValueOr(value byte) byte {
+	return ns.null().ValueOr(value)
+}
+
+// IsZero //
+func (ns NullByte) IsZero() bool {
+	return !ns.Valid
+}
+
+// Scan accepts the same representations as NullInt64.Scan, within the
+// range of a byte.
+func (ns *NullByte) Scan(value interface{}) error {
+	if value == nil {
+		ns.Byte, ns.Valid = 0, false
+		return nil
+	}
+	v, err := scanInt(value, 0, math.MaxUint8, "NullByte")
+	if err != nil {
+		return err
+	}
+	ns.Byte, ns.Valid = byte(v), true
+	return nil
+}
+
+// Value //
+func (ns NullByte) Value() (driver.Value, error) {
+	return ns.null().Value()
+}
+
+// MarshalJSON //
+func (ns NullByte) MarshalJSON() ([]byte, error) {
+	return ns.null().MarshalJSON()
+}
+
+// UnmarshalJSON //
+func (ns *NullByte) UnmarshalJSON(data []byte) error {
+	n := Null[byte]{}
+	err := n.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	ns.Byte = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXML //
+func (ns NullByte) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return ns.null().MarshalXML(e, start)
+}
+
+// UnmarshalXML //
+func (ns *NullByte) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	n := Null[byte]{}
+	err := n.UnmarshalXML(d, start)
+	if err != nil {
+		return err
+	}
+	ns.Byte = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXMLAttr //
+func (ns NullByte) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return ns.null().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullByte) UnmarshalXMLAttr(attr xml.Attr) error {
+	n := Null[byte]{}
+	err := n.UnmarshalXMLAttr(attr)
+	if err != nil {
+		return err
+	}
+	ns.Byte = n.V
+	ns.Valid = n.Valid
+	return nil
+}
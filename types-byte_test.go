@@ -0,0 +1,160 @@
+package sqljson_test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNullByteValidateValuer(t *testing.T) {
+	Convey("Given a non-null sqljson.NullByte value", t, func() {
+		ov := sqljson.NullByte{
+			NullByte: sql.NullByte{
+				Byte:  255,
+				Valid: true,
+			},
+		}
+		Convey("When evaluated", func() {
+			ivOut := sqljson.NullByteValidateValuer(reflect.ValueOf(ov))
+			Convey("Then we should get the value", func() {
+				nv, ok := ivOut.(byte)
+				So(ok, ShouldBeTrue)
+				So(nv, ShouldEqual, 255)
+			})
+		})
+	})
+	Convey("Given a null sqljson.NullByte value", t, func() {
+		ov := sqljson.NullByte{}
+		Convey("When evaluated", func() {
+			ivOut := sqljson.NullByteValidateValuer(reflect.ValueOf(ov))
+			Convey("Then we should get nil", func() {
+				So(ivOut, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestBytePtrOrNil(t *testing.T) {
+	Convey("Given a valid non-Null NullByte value", t, func() {
+		value := sqljson.NullByte{NullByte: sql.NullByte{Byte: 255, Valid: true}}
+		Convey("When we get the BytePtrOrNil", func() {
+			res := value.BytePtrOrNil()
+			Convey("Then we get the value", func() {
+				So(res, ShouldNotBeNil)
+				So(*res, ShouldEqual, 255)
+			})
+		})
+	})
+	Convey("Given a valid null NullByte value", t, func() {
+		value := sqljson.NullByte{}
+		Convey("When we get the BytePtrOrNil", func() {
+			res := value.BytePtrOrNil()
+			Convey("Then we get nil", func() {
+				So(res, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestByteMarshalJSON(t *testing.T) {
+	Convey("Given a non-null sqljson.NullByte value", t, func() {
+		valueIn := sqljson.NullByte{NullByte: sql.NullByte{Byte: 255, Valid: true}}
+		Convey("When I marshal it", func() {
+			b, err := valueIn.MarshalJSON()
+			Convey("Then I should get the number", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `255`)
+			})
+		})
+	})
+	Convey("Given a null sqljson.NullByte value", t, func() {
+		valueIn := sqljson.NullByte{}
+		Convey("When I marshal it", func() {
+			b, err := valueIn.MarshalJSON()
+			Convey("Then I should get null", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `null`)
+			})
+		})
+	})
+}
+
+func TestByteUnmarshalJSON(t *testing.T) {
+	Convey("Given a sqljson.NullByte value pointer, and a number JSON value", t, func() {
+		ns := &sqljson.NullByte{}
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := ns.UnmarshalJSON([]byte(`255`))
+			Convey("Then I should get a not-Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeTrue)
+				So(ns.Byte, ShouldEqual, 255)
+			})
+		})
+	})
+	Convey("Given a sqljson.NullByte value pointer, and a null JSON value", t, func() {
+		ns := &sqljson.NullByte{NullByte: sql.NullByte{Byte: 255, Valid: true}}
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := ns.UnmarshalJSON([]byte(`null`))
+			Convey("Then I should get a Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a sqljson.NullByte value pointer, and an out of range JSON value", t, func() {
+		ns := &sqljson.NullByte{}
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := ns.UnmarshalJSON([]byte(`256`))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "cannot unmarshal number 256 into Go value of type uint8")
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestByteScan(t *testing.T) {
+	Convey("Given a sqljson.NullByte value pointer", t, func() {
+		ns := &sqljson.NullByte{}
+		Convey("When I scan an out of range value", func() {
+			err := ns.Scan(int64(256))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "value out of range")
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestByteConstructors(t *testing.T) {
+	Convey("Given a byte value, a pointer to it, a nil pointer and the zero value", t, func() {
+		value := byte(200)
+		Convey("When I build sqljson.NullByte values from them", func() {
+			fromValue := sqljson.NewNullByte(value)
+			fromPtr := sqljson.NullByteFrom(&value)
+			fromNil := sqljson.NullByteFrom(nil)
+			fromZero := sqljson.NullByteFromZero(byte(0))
+			fromNonZero := sqljson.NullByteFromZero(value)
+			Convey("Then only the nil pointer and the zero value should be NULL", func() {
+				So(fromValue.Valid, ShouldBeTrue)
+				So(fromValue.Byte, ShouldEqual, value)
+				So(fromPtr.Valid, ShouldBeTrue)
+				So(fromPtr.Byte, ShouldEqual, value)
+				So(fromNil.Valid, ShouldBeFalse)
+				So(fromZero.Valid, ShouldBeFalse)
+				So(fromNonZero.Valid, ShouldBeTrue)
+			})
+			Convey("Then ValueOrZero and ValueOr should fall back only when NULL", func() {
+				So(fromValue.ValueOrZero(), ShouldEqual, value)
+				So(fromValue.ValueOr(byte(1)), ShouldEqual, value)
+				So(fromNil.ValueOrZero(), ShouldEqual, byte(0))
+				So(fromNil.ValueOr(byte(1)), ShouldEqual, byte(1))
+			})
+		})
+	})
+}
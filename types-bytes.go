@@ -0,0 +1,239 @@
+package sqljson
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BytesEncoding is a text encoding for binary data.
+type BytesEncoding int
+
+const (
+	// BytesBase64 is standard base64, with padding.
+	BytesBase64 BytesEncoding = iota
+	// BytesBase64URL is URL-safe base64, without padding.
+	BytesBase64URL
+	// BytesHex is lower-case hexadecimal.
+	BytesHex
+)
+
+// BytesTextEncoding is the encoding NullBytes uses in JSON, text and XML.
+var BytesTextEncoding = BytesBase64
+
+// encodeBytes encodes data with BytesTextEncoding.
+func encodeBytes(data []byte) string {
+	switch BytesTextEncoding {
+	case BytesBase64URL:
+		return base64.RawURLEncoding.EncodeToString(data)
+	case BytesHex:
+		return hex.EncodeToString(data)
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// decodeBytes decodes text encoded with BytesTextEncoding. Base64 is
+// accepted with or without padding.
+func decodeBytes(text string) ([]byte, error) {
+	var data []byte
+	var err error
+	switch BytesTextEncoding {
+	case BytesBase64URL:
+		data, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(text, "="))
+	case BytesHex:
+		data, err = hex.DecodeString(text)
+	default:
+		data, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(text, "="))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sqljson: cannot decode bytes: %w", err)
+	}
+	return data, nil
+}
+
+// NullBytes is a nullable byte slice, as stored in BLOB and BYTEA columns.
+type NullBytes struct {
+	Bytes []byte
+	Valid bool
+}
+
+// NewNullBytes //
+func NewNullBytes(value []byte) NullBytes {
+	return NullBytes{Bytes: value, Valid: true}
+}
+
+// NullBytesFrom //
+func NullBytesFrom(value *[]byte) NullBytes {
+	if value == nil {
+		return NullBytes{}
+	}
+	return NewNullBytes(*value)
+}
+
+// NullBytesFromZero returns NULL for a nil or empty slice.
+func NullBytesFromZero(value []byte) NullBytes {
+	if len(value) == 0 {
+		return NullBytes{}
+	}
+	return NewNullBytes(value)
+}
+
+// NullBytesValidateValuer returns the bytes, so that length validations such
+// as min, max and len can be applied to them.
+func NullBytesValidateValuer(field reflect.Value) interface{} {
+	if nullBytes, ok := field.Interface().(NullBytes); ok {
+		return nullBytes.ValidateValue()
+	}
+	return nil
+}
+
+// ValidateValue //
+func (ns NullBytes) ValidateValue() interface{} {
+	if ns.Valid {
+		return ns.ValueOrZero()
+	}
+	return nil
+}
+
+// BytesPtrOrNil //
+func (ns NullBytes) BytesPtrOrNil() *[]byte {
+	if ns.Valid {
+		b := ns.Bytes
+		return &b
+	}
+	return nil
+}
+
+// ValueOrZero returns the bytes, or an empty slice when NULL.
+func (ns NullBytes) ValueOrZero() []byte {
+	return ns.ValueOr([]byte{})
+}
+
+// ValueOr //
+func (ns NullBytes) ValueOr(value []byte) []byte {
+	if ns.Valid {
+		if ns.Bytes == nil {
+			return []byte{}
+		}
+		return ns.Bytes
+	}
+	return value
+}
+
+// IsZero //
+func (ns NullBytes) IsZero() bool {
+	return !ns.Valid
+}
+
+// Scan copies the bytes, as drivers may reuse their buffer on the next call
+// to Next.
+func (ns *NullBytes) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		ns.Bytes, ns.Valid = nil, false
+		return nil
+	case []byte:
+		ns.Bytes, ns.Valid = append([]byte{}, v...), true
+		return nil
+	case string:
+		ns.Bytes, ns.Valid = []byte(v), true
+		return nil
+	}
+	return fmt.Errorf("sqljson: cannot scan %T into NullBytes", value)
+}
+
+// Value //
+func (ns NullBytes) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return ns.ValueOrZero(), nil
+}
+
+// text returns the text of a non-NULL value.
+func (ns NullBytes) text() string {
+	return encodeBytes(ns.Bytes)
+}
+
+// setText sets ns to the non-NULL value given as text.
+func (ns *NullBytes) setText(text string) error {
+	value, err := decodeBytes(strings.TrimSpace(text))
+	if err != nil {
+		return err
+	}
+	ns.Bytes, ns.Valid = value, true
+	return nil
+}
+
+// MarshalJSON marshals the bytes as a string in BytesTextEncoding.
+func (ns NullBytes) MarshalJSON() ([]byte, error) {
+	if !ns.Valid {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(ns.text())
+}
+
+// UnmarshalJSON //
+func (ns *NullBytes) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		ns.Bytes, ns.Valid = nil, false
+		return nil
+	}
+	var text string
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return err
+	}
+	return ns.setText(text)
+}
+
+// MarshalText //
+func (ns NullBytes) MarshalText() ([]byte, error) {
+	if !ns.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(ns.text()), nil
+}
+
+// UnmarshalText //
+func (ns *NullBytes) UnmarshalText(data []byte) error {
+	if string(data) == NullText {
+		ns.Bytes, ns.Valid = nil, false
+		return nil
+	}
+	return ns.setText(string(data))
+}
+
+// MarshalXML //
+func (ns NullBytes) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(e, start, ns.text(), ns.Valid)
+}
+
+// UnmarshalXML //
+func (ns *NullBytes) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	text, ok, err := unmarshalXMLText(d, start)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		ns.Bytes, ns.Valid = nil, false
+		return nil
+	}
+	return ns.setText(text)
+}
+
+// MarshalXMLAttr //
+func (ns NullBytes) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, ns.text(), ns.Valid)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullBytes) UnmarshalXMLAttr(attr xml.Attr) error {
+	return ns.setText(attr.Value)
+}
@@ -0,0 +1,136 @@
+package sqljson_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	validator "gopkg.in/go-playground/validator.v9"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBytesJSON(t *testing.T) {
+	data := []byte{0xfb, 0xff, 0x00, 0x01}
+	cases := []struct {
+		encoding sqljson.BytesEncoding
+		text     string
+	}{
+		{sqljson.BytesBase64, `"+/8AAQ=="`},
+		{sqljson.BytesBase64URL, `"-_8AAQ"`},
+		{sqljson.BytesHex, `"fbff0001"`},
+	}
+	Convey("Given a valid sqljson.NullBytes", t, func() {
+		ns := sqljson.NewNullBytes(data)
+		Convey("When I marshal and unmarshal it with each encoding", func() {
+			defer func() { sqljson.BytesTextEncoding = sqljson.BytesBase64 }()
+			for _, c := range cases {
+				sqljson.BytesTextEncoding = c.encoding
+				out, err := json.Marshal(ns)
+				So(err, ShouldBeNil)
+				So(string(out), ShouldEqual, c.text)
+				var back sqljson.NullBytes
+				So(json.Unmarshal(out, &back), ShouldBeNil)
+				So(back.Valid, ShouldBeTrue)
+				So(back.Bytes, ShouldResemble, data)
+			}
+		})
+	})
+	Convey("Given JSON null, unpadded base64 and invalid base64", t, func() {
+		Convey("When I unmarshal them", func() {
+			var null, unpadded, invalid sqljson.NullBytes
+			errNull := json.Unmarshal([]byte(`null`), &null)
+			errUnpadded := json.Unmarshal([]byte(`"+/8AAQ"`), &unpadded)
+			errInvalid := json.Unmarshal([]byte(`"not base64!"`), &invalid)
+			Convey("Then I should get NULL, the bytes, and an error", func() {
+				So(errNull, ShouldBeNil)
+				So(null.Valid, ShouldBeFalse)
+				So(errUnpadded, ShouldBeNil)
+				So(unpadded.Bytes, ShouldResemble, data)
+				So(errInvalid, ShouldNotBeNil)
+			})
+		})
+	})
+	Convey("Given a null sqljson.NullBytes", t, func() {
+		Convey("When I marshal it", func() {
+			out, err := json.Marshal(sqljson.NullBytes{})
+			Convey("Then I should get null", func() {
+				So(err, ShouldBeNil)
+				So(string(out), ShouldEqual, "null")
+			})
+		})
+	})
+}
+
+func TestBytesSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	Convey("Given a sql mock returning a BLOB, an empty BLOB and NULL", t, func() {
+		blob := []byte("thumbnail")
+		mock.
+			ExpectQuery(`SELECT a, b, c FROM images`).
+			WillReturnRows(sqlmock.NewRows([]string{"a", "b", "c"}).AddRow(blob, []byte{}, nil))
+		Convey("When I query a row and scan it", func() {
+			a, b, c := sqljson.NullBytes{}, sqljson.NullBytes{}, sqljson.NullBytes{}
+			dbErr := db.QueryRow(`SELECT a, b, c FROM images`).Scan(&a, &b, &c)
+			Convey("Then I should get copies of the bytes", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+				So(a.Bytes, ShouldResemble, []byte("thumbnail"))
+				blob[0] = 'T'
+				So(a.Bytes, ShouldResemble, []byte("thumbnail"))
+				So(b.Valid, ShouldBeTrue)
+				So(b.Bytes, ShouldResemble, []byte{})
+				So(c.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a sqljson.NullBytes scanned from a reused buffer", t, func() {
+		buf := []byte("hash")
+		var ns sqljson.NullBytes
+		So(ns.Scan(buf), ShouldBeNil)
+		Convey("When the buffer is overwritten", func() {
+			copy(buf, "XXXX")
+			Convey("Then the scanned bytes should not change", func() {
+				So(string(ns.Bytes), ShouldEqual, "hash")
+			})
+		})
+	})
+	Convey("Given a valid empty and a null sqljson.NullBytes", t, func() {
+		Convey("When I get their values", func() {
+			empty, errEmpty := sqljson.NewNullBytes(nil).Value()
+			null, errNull := sqljson.NullBytes{}.Value()
+			Convey("Then I should get an empty slice and nil", func() {
+				So(errEmpty, ShouldBeNil)
+				So(empty, ShouldResemble, []byte{})
+				So(errNull, ShouldBeNil)
+				So(null, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestBytesValidator(t *testing.T) {
+	type validatorStruct struct {
+		Hash sqljson.NullBytes `validate:"required,len=4"`
+	}
+	Convey("Given a validator from NewValidator", t, func() {
+		validate := sqljson.NewValidator()
+		Convey("When I validate bytes of the right and wrong lengths, and NULL", func() {
+			errRight := validate.Struct(&validatorStruct{Hash: sqljson.NewNullBytes([]byte("hash"))})
+			errWrong := validate.Struct(&validatorStruct{Hash: sqljson.NewNullBytes([]byte("sha256"))})
+			errNull := validate.Struct(&validatorStruct{})
+			Convey("Then I should get appropriate results", func() {
+				So(errRight, ShouldBeNil)
+				So(errWrong.(validator.ValidationErrors)[0].Tag(), ShouldEqual, "len")
+				So(errNull.(validator.ValidationErrors)[0].Tag(), ShouldEqual, "required")
+			})
+		})
+	})
+}
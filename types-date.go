@@ -0,0 +1,254 @@
+package sqljson
+
+import (
+	"bytes"
+	"cmp"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DateLayout is the layout of dates in JSON, text, XML and the database.
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date, without a time of day or a time zone, as stored in
+// SQL DATE columns.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// ParseDate parses a date in DateLayout, such as "2006-01-02".
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("sqljson: invalid date %q", s)
+	}
+	return DateOf(t), nil
+}
+
+// DateOf returns the date of t in its own location, so that a DATE scanned
+// as midnight UTC keeps its day.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// String returns the date in DateLayout.
+func (d Date) String() string {
+	return d.In(time.UTC).Format(DateLayout)
+}
+
+// In returns the time at midnight of the date in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// Compare returns -1, 0 or +1 when d is before, the same as, or after other.
+func (d Date) Compare(other Date) int {
+	if c := cmp.Compare(d.Year, other.Year); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(d.Month, other.Month); c != 0 {
+		return c
+	}
+	return cmp.Compare(d.Day, other.Day)
+}
+
+// scanDate converts the driver representations of a date: times, and texts
+// starting with a date, such as "2006-01-02" or "2006-01-02 00:00:00".
+func scanDate(value interface{}) (Date, error) {
+	if t, ok := value.(time.Time); ok {
+		return DateOf(t), nil
+	}
+	text, ok := scanText(value)
+	if !ok {
+		return Date{}, fmt.Errorf("sqljson: cannot scan %T into NullDate", value)
+	}
+	if len(text) > len(DateLayout) && (text[len(DateLayout)] == ' ' || text[len(DateLayout)] == 'T') {
+		text = text[:len(DateLayout)]
+	}
+	return ParseDate(text)
+}
+
+// NullDate is a nullable calendar date. Keeping the date apart from any time
+// zone avoids the day shifting when a DATE goes through a timestamp.
+type NullDate struct {
+	Date  Date
+	Valid bool
+}
+
+// NewNullDate //
+func NewNullDate(value Date) NullDate {
+	return NullDate{Date: value, Valid: true}
+}
+
+// NullDateFrom //
+func NullDateFrom(value *Date) NullDate {
+	if value == nil {
+		return NullDate{}
+	}
+	return NewNullDate(*value)
+}
+
+// NullDateFromZero //
+func NullDateFromZero(value Date) NullDate {
+	if value == (Date{}) {
+		return NullDate{}
+	}
+	return NewNullDate(value)
+}
+
+// NullDateValidateValuer //
+func NullDateValidateValuer(field reflect.Value) interface{} {
+	if nullDate, ok := field.Interface().(NullDate); ok {
+		return nullDate.ValidateValue()
+	}
+	return nil
+}
+
+// ValidateValue returns the date in DateLayout, which sorts like the date,
+// for the date_min and date_max tags and for cross-field comparisons.
+func (ns NullDate) ValidateValue() interface{} {
+	if ns.Valid {
+		return ns.text()
+	}
+	return nil
+}
+
+// DatePtrOrNil //
+func (ns NullDate) DatePtrOrNil() *Date {
+	if ns.Valid {
+		d := ns.Date
+		return &d
+	}
+	return nil
+}
+
+// ValueOrZero //
+func (ns NullDate) ValueOrZero() Date {
+	return ns.ValueOr(Date{})
+}
+
+// ValueOr //
+func (ns NullDate) ValueOr(value Date) Date {
+	if ns.Valid {
+		return ns.Date
+	}
+	return value
+}
+
+// IsZero //
+func (ns NullDate) IsZero() bool {
+	return !ns.Valid
+}
+
+// Scan //
+func (ns *NullDate) Scan(value interface{}) error {
+	if value == nil {
+		ns.Date, ns.Valid = Date{}, false
+		return nil
+	}
+	date, err := scanDate(value)
+	if err != nil {
+		return err
+	}
+	ns.Date, ns.Valid = date, true
+	return nil
+}
+
+// Value writes the date as text in DateLayout, which every common driver
+// accepts for DATE columns.
+func (ns NullDate) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return ns.text(), nil
+}
+
+// text returns the text of a non-NULL value.
+func (ns NullDate) text() string {
+	return ns.Date.String()
+}
+
+// setText sets ns to the non-NULL value given as text.
+func (ns *NullDate) setText(text string) error {
+	value, err := ParseDate(strings.TrimSpace(text))
+	if err != nil {
+		return err
+	}
+	ns.Date, ns.Valid = value, true
+	return nil
+}
+
+// MarshalJSON //
+func (ns NullDate) MarshalJSON() ([]byte, error) {
+	if !ns.Valid {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(ns.text())
+}
+
+// UnmarshalJSON //
+func (ns *NullDate) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		ns.Date, ns.Valid = Date{}, false
+		return nil
+	}
+	var text string
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return err
+	}
+	return ns.setText(text)
+}
+
+// MarshalText //
+func (ns NullDate) MarshalText() ([]byte, error) {
+	if !ns.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(ns.text()), nil
+}
+
+// UnmarshalText //
+func (ns *NullDate) UnmarshalText(data []byte) error {
+	if isNullText(data) {
+		ns.Date, ns.Valid = Date{}, false
+		return nil
+	}
+	return ns.setText(string(data))
+}
+
+// MarshalXML //
+func (ns NullDate) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(e, start, ns.text(), ns.Valid)
+}
+
+// UnmarshalXML //
+func (ns *NullDate) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	text, ok, err := unmarshalXMLText(d, start)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		ns.Date, ns.Valid = Date{}, false
+		return nil
+	}
+	return ns.setText(text)
+}
+
+// MarshalXMLAttr //
+func (ns NullDate) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, ns.text(), ns.Valid)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullDate) UnmarshalXMLAttr(attr xml.Attr) error {
+	return ns.setText(attr.Value)
+}
@@ -0,0 +1,119 @@
+package sqljson_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rhaseven7h/sqljson"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDateJSON(t *testing.T) {
+	type jsonStruct struct {
+		Birthday sqljson.NullDate `json:"birthday"`
+		Deceased sqljson.NullDate `json:"deceased"`
+	}
+	Convey("Given JSON with a date and a null", t, func() {
+		data := []byte(`{"birthday":"1990-02-28","deceased":null}`)
+		Convey("When I unmarshal it", func() {
+			var v jsonStruct
+			err := json.Unmarshal(data, &v)
+			Convey("Then I should get the date and NULL", func() {
+				So(err, ShouldBeNil)
+				So(v.Birthday.Date, ShouldResemble, sqljson.Date{Year: 1990, Month: time.February, Day: 28})
+				So(v.Deceased.Valid, ShouldBeFalse)
+			})
+			Convey("And marshaling it back should give the same JSON", func() {
+				out, errMarshal := json.Marshal(v)
+				So(errMarshal, ShouldBeNil)
+				So(string(out), ShouldEqual, string(data))
+			})
+		})
+	})
+	Convey("Given JSON with invalid dates", t, func() {
+		Convey("When I unmarshal them", func() {
+			var v jsonStruct
+			errDay := json.Unmarshal([]byte(`{"birthday":"1990-02-30"}`), &v)
+			errTime := json.Unmarshal([]byte(`{"birthday":"1990-02-28T10:00:00Z"}`), &v)
+			Convey("Then I should get errors", func() {
+				So(errDay, ShouldNotBeNil)
+				So(errTime, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestDateSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	Convey("Given a sql mock returning dates as times, bytes, strings and NULL", t, func() {
+		tokyo := time.FixedZone("JST", 9*60*60)
+		mock.
+			ExpectQuery(`SELECT a, b, c, d, e FROM people`).
+			WillReturnRows(sqlmock.NewRows([]string{"a", "b", "c", "d", "e"}).
+				AddRow(time.Date(1990, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(1990, 2, 28, 0, 0, 0, 0, tokyo),
+					[]byte("1990-02-28"), "1990-02-28 00:00:00", nil))
+		Convey("When I query a row and scan it", func() {
+			a, b, c, d, e := sqljson.NullDate{}, sqljson.NullDate{}, sqljson.NullDate{}, sqljson.NullDate{}, sqljson.NullDate{}
+			dbErr := db.QueryRow(`SELECT a, b, c, d, e FROM people`).Scan(&a, &b, &c, &d, &e)
+			Convey("Then every representation should give the same day", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+				for _, date := range []sqljson.NullDate{a, b, c, d} {
+					So(date.Valid, ShouldBeTrue)
+					So(date.Date.String(), ShouldEqual, "1990-02-28")
+				}
+				So(e.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a valid sqljson.NullDate", t, func() {
+		Convey("When I get its value", func() {
+			value, err := sqljson.NewNullDate(sqljson.Date{Year: 2024, Month: time.May, Day: 1}).Value()
+			Convey("Then I should get the date as text", func() {
+				So(err, ShouldBeNil)
+				So(value, ShouldEqual, "2024-05-01")
+			})
+		})
+	})
+}
+
+func TestDateValidator(t *testing.T) {
+	type validatorStruct struct {
+		Birthday sqljson.NullDate `json:"birthday" validate:"required,date_min=1900-01-01,date_max=today"`
+	}
+	type nullTagsStruct struct {
+		Birthday sqljson.NullDate `json:"birthday"`
+		Hired    sqljson.NullDate `json:"hired" validate:"omitempty,null_gtfield=Birthday"`
+	}
+	Convey("Given a validator from NewValidator", t, func() {
+		validate := sqljson.NewValidator()
+		Convey("When I validate dates in and out of range", func() {
+			birthday, _ := sqljson.ParseDate("1990-02-28")
+			hired, _ := sqljson.ParseDate("2015-09-01")
+			errIn := validate.Struct(&validatorStruct{Birthday: sqljson.NewNullDate(birthday)})
+			errOld := validate.Struct(&validatorStruct{Birthday: sqljson.NewNullDate(sqljson.Date{Year: 1899, Month: time.December, Day: 31})})
+			errFuture := validate.Struct(&validatorStruct{Birthday: sqljson.NewNullDate(sqljson.DateOf(time.Now().AddDate(0, 0, 2)))})
+			Convey("Then I should get appropriate results", func() {
+				So(errIn, ShouldBeNil)
+				So(validationTags(errOld), ShouldResemble, map[string]string{"birthday": "date_min"})
+				So(validationTags(errFuture), ShouldResemble, map[string]string{"birthday": "date_max"})
+			})
+			Convey("And with RegisterNullTags, null_gtfield should compare dates", func() {
+				validate := nullTagsValidator()
+				errOrdered := validate.Struct(&nullTagsStruct{Birthday: sqljson.NewNullDate(birthday), Hired: sqljson.NewNullDate(hired)})
+				errReversed := validate.Struct(&nullTagsStruct{Birthday: sqljson.NewNullDate(hired), Hired: sqljson.NewNullDate(birthday)})
+				So(errOrdered, ShouldBeNil)
+				So(validationTags(errReversed), ShouldResemble, map[string]string{"hired": "null_gtfield"})
+			})
+		})
+	})
+}
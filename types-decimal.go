@@ -0,0 +1,288 @@
+package sqljson
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DecimalJSONString makes NullDecimal marshal to JSON as a string, such as
+// "12.50", instead of as a number, for clients that would round the number.
+var DecimalJSONString = false
+
+// maxDecimalExponent bounds the exponents accepted by NullDecimal, which are
+// expanded into plain notation.
+const maxDecimalExponent = 1000
+
+// NullDecimal is a nullable exact decimal number, as stored in NUMERIC and
+// DECIMAL columns. It keeps its value as decimal text, such as "12.50", so it
+// never goes through a float on its way between the database and JSON.
+type NullDecimal struct {
+	Decimal string
+	Valid   bool
+}
+
+// NewNullDecimal //
+func NewNullDecimal(value string) NullDecimal {
+	return NullDecimal{Decimal: value, Valid: true}
+}
+
+// NullDecimalFrom //
+func NullDecimalFrom(value *string) NullDecimal {
+	if value == nil {
+		return NullDecimal{}
+	}
+	return NewNullDecimal(*value)
+}
+
+// NullDecimalFromZero returns NULL for an empty string and for any decimal
+// equal to zero, such as "0.00".
+func NullDecimalFromZero(value string) NullDecimal {
+	decimal, err := parseDecimal(value)
+	if value == "" || err == nil && strings.Trim(decimal, "0.") == "" {
+		return NullDecimal{}
+	}
+	return NewNullDecimal(value)
+}
+
+// parseDecimal validates a decimal number, optionally with an exponent, and
+// returns it in plain notation, keeping the digits written: "012.0" becomes
+// "12.0", "1.2e1" becomes "12" and "1e-3" becomes "0.001".
+func parseDecimal(s string) (string, error) {
+	text := strings.TrimSpace(s)
+	negative := false
+	if text != "" && (text[0] == '-' || text[0] == '+') {
+		negative = text[0] == '-'
+		text = text[1:]
+	}
+	exponent := 0
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		e, err := strconv.Atoi(text[i+1:])
+		if err != nil || e > maxDecimalExponent || e < -maxDecimalExponent {
+			return "", fmt.Errorf("sqljson: invalid decimal %q", s)
+		}
+		exponent = e
+		text = text[:i]
+	}
+	intPart, fracPart := text, ""
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		intPart, fracPart = text[:i], text[i+1:]
+	}
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return "", fmt.Errorf("sqljson: invalid decimal %q", s)
+	}
+	digits := intPart + fracPart
+	point := len(intPart) + exponent
+	if point < 0 {
+		digits = strings.Repeat("0", -point) + digits
+		point = 0
+	}
+	if point > len(digits) {
+		digits += strings.Repeat("0", point-len(digits))
+	}
+	intPart = strings.TrimLeft(digits[:point], "0")
+	if intPart == "" {
+		intPart = "0"
+	}
+	fracPart = digits[point:]
+	result := intPart
+	if fracPart != "" {
+		result += "." + fracPart
+	}
+	if negative && strings.Trim(digits, "0") != "" {
+		result = "-" + result
+	}
+	return result, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// NullDecimalValidateValuer returns the decimal as a float64, so that
+// numeric validations such as min and max can be applied to it.
+func NullDecimalValidateValuer(field reflect.Value) interface{} {
+	if nullDecimal, ok := field.Interface().(NullDecimal); ok {
+		return nullDecimal.ValidateValue()
+	}
+	return nil
+}
+
+// ValidateValue //
+func (ns NullDecimal) ValidateValue() interface{} {
+	if ns.Valid {
+		f, _ := strconv.ParseFloat(ns.Decimal, 64)
+		return f
+	}
+	return nil
+}
+
+// DecimalPtrOrNil //
+func (ns NullDecimal) DecimalPtrOrNil() *string {
+	if ns.Valid {
+		s := ns.Decimal
+		return &s
+	}
+	return nil
+}
+
+// ValueOrZero //
+func (ns NullDecimal) ValueOrZero() string {
+	return ns.ValueOr("0")
+}
+
+// ValueOr //
+func (ns NullDecimal) ValueOr(value string) string {
+	if ns.Valid {
+		return ns.Decimal
+	}
+	return value
+}
+
+// IsZero //
+func (ns NullDecimal) IsZero() bool {
+	return !ns.Valid
+}
+
+// Rat returns the exact value of the decimal, or nil when it is NULL.
+func (ns NullDecimal) Rat() *big.Rat {
+	if ns.Valid {
+		if r, ok := new(big.Rat).SetString(ns.Decimal); ok {
+			return r
+		}
+	}
+	return nil
+}
+
+// Scan //
+func (ns *NullDecimal) Scan(value interface{}) error {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		ns.Decimal, ns.Valid = "", false
+		return nil
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	case int64:
+		text = strconv.FormatInt(v, 10)
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Errorf("sqljson: cannot scan %T into NullDecimal", value)
+	}
+	decimal, err := parseDecimal(text)
+	if err != nil {
+		return err
+	}
+	ns.Decimal, ns.Valid = decimal, true
+	return nil
+}
+
+// Value //
+func (ns NullDecimal) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return parseDecimal(ns.Decimal)
+}
+
+// MarshalJSON //
+func (ns NullDecimal) MarshalJSON() ([]byte, error) {
+	if !ns.Valid {
+		return json.Marshal(nil)
+	}
+	decimal, err := parseDecimal(ns.Decimal)
+	if err != nil {
+		return nil, err
+	}
+	if DecimalJSONString {
+		return json.Marshal(decimal)
+	}
+	return []byte(decimal), nil
+}
+
+// UnmarshalJSON accepts the decimal both as a JSON number and as a string.
+func (ns *NullDecimal) UnmarshalJSON(data []byte) error {
+	text := string(bytes.TrimSpace(data))
+	if text == "null" {
+		ns.Decimal, ns.Valid = "", false
+		return nil
+	}
+	if strings.HasPrefix(text, `"`) {
+		err := json.Unmarshal(data, &text)
+		if err != nil {
+			return err
+		}
+	}
+	decimal, err := parseDecimal(text)
+	if err != nil {
+		return err
+	}
+	ns.Decimal, ns.Valid = decimal, true
+	return nil
+}
+
+// MarshalXML //
+func (ns NullDecimal) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !ns.Valid {
+		return marshalXMLNull(e, start)
+	}
+	decimal, err := parseDecimal(ns.Decimal)
+	if err != nil {
+		return err
+	}
+	return marshalXMLText(e, start, decimal, true)
+}
+
+// UnmarshalXML //
+func (ns *NullDecimal) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	text, ok, err := unmarshalXMLText(d, start)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		ns.Decimal, ns.Valid = "", false
+		return nil
+	}
+	decimal, err := parseDecimal(strings.TrimSpace(text))
+	if err != nil {
+		return err
+	}
+	ns.Decimal, ns.Valid = decimal, true
+	return nil
+}
+
+// MarshalXMLAttr //
+func (ns NullDecimal) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !ns.Valid {
+		return xml.Attr{}, nil
+	}
+	decimal, err := parseDecimal(ns.Decimal)
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return marshalXMLAttrText(name, decimal, true)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullDecimal) UnmarshalXMLAttr(attr xml.Attr) error {
+	decimal, err := parseDecimal(strings.TrimSpace(attr.Value))
+	if err != nil {
+		return err
+	}
+	ns.Decimal, ns.Valid = decimal, true
+	return nil
+}
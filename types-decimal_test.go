@@ -0,0 +1,228 @@
+package sqljson_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	validator "gopkg.in/go-playground/validator.v9"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNullDecimalValidateValuer(t *testing.T) {
+	Convey("Given a non-null sqljson.NullDecimal value", t, func() {
+		ov := sqljson.NullDecimal{Decimal: "555.55", Valid: true}
+		Convey("When evaluated", func() {
+			ivOut := sqljson.NullDecimalValidateValuer(reflect.ValueOf(ov))
+			Convey("Then we should get the value as a float64", func() {
+				So(ivOut, ShouldEqual, 555.55)
+			})
+		})
+	})
+	Convey("Given a null sqljson.NullDecimal value", t, func() {
+		ov := sqljson.NullDecimal{}
+		Convey("When evaluated", func() {
+			ivOut := sqljson.NullDecimalValidateValuer(reflect.ValueOf(ov))
+			Convey("Then we should get nil", func() {
+				So(ivOut, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a validator and a struct with a sqljson.NullDecimal", t, func() {
+		validate := validator.New()
+		So(sqljson.RegisterValidator(validate), ShouldBeNil)
+		type validatorStruct struct {
+			BankBalance sqljson.NullDecimal `validate:"required,min=0,max=555.55"`
+		}
+		Convey("When I validate values around the limits", func() {
+			errMax := validate.Struct(&validatorStruct{BankBalance: sqljson.NullDecimal{Decimal: "555.55", Valid: true}})
+			errOver := validate.Struct(&validatorStruct{BankBalance: sqljson.NullDecimal{Decimal: "555.56", Valid: true}})
+			errUnder := validate.Struct(&validatorStruct{BankBalance: sqljson.NullDecimal{Decimal: "-0.01", Valid: true}})
+			Convey("Then I should get appropriate results", func() {
+				So(errMax, ShouldBeNil)
+				So(errOver, ShouldNotBeNil)
+				So(errOver.(validator.ValidationErrors)[0].Tag(), ShouldEqual, "max")
+				So(errUnder, ShouldNotBeNil)
+				So(errUnder.(validator.ValidationErrors)[0].Tag(), ShouldEqual, "min")
+			})
+		})
+	})
+}
+
+func TestDecimalMarshalJSON(t *testing.T) {
+	Convey("Given a non-null sqljson.NullDecimal value", t, func() {
+		valueIn := sqljson.NullDecimal{Decimal: "12345678901234567890.10", Valid: true}
+		Convey("When I marshal it", func() {
+			b, err := json.Marshal(valueIn)
+			Convey("Then I should get an exact number", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `12345678901234567890.10`)
+			})
+		})
+		Convey("When I marshal it as a string", func() {
+			sqljson.DecimalJSONString = true
+			b, err := json.Marshal(valueIn)
+			sqljson.DecimalJSONString = false
+			Convey("Then I should get an exact string", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `"12345678901234567890.10"`)
+			})
+		})
+	})
+	Convey("Given a null sqljson.NullDecimal value", t, func() {
+		valueIn := sqljson.NullDecimal{}
+		Convey("When I marshal it", func() {
+			b, err := valueIn.MarshalJSON()
+			Convey("Then I should get null", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `null`)
+			})
+		})
+	})
+	Convey("Given a sqljson.NullDecimal value holding something else than a decimal", t, func() {
+		valueIn := sqljson.NullDecimal{Decimal: "12,50", Valid: true}
+		Convey("When I marshal it", func() {
+			_, err := valueIn.MarshalJSON()
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, `invalid decimal "12,50"`)
+			})
+		})
+	})
+}
+
+func TestDecimalUnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		in  string
+		out string
+	}{
+		{`0.10`, "0.10"},
+		{`"0.10"`, "0.10"},
+		{`-12`, "-12"},
+		{`1.5e2`, "150"},
+		{`"1.25E-3"`, "0.00125"},
+		{`"+007.50"`, "7.50"},
+		{`-0.0`, "0.0"},
+		{`1.2e1`, "12"},
+		{`"012.0"`, "12.0"},
+		{`1e-3`, "0.001"},
+		{`"1e1000"`, "1" + strings.Repeat("0", 1000)},
+		{`"1e-1000"`, "0." + strings.Repeat("0", 999) + "1"},
+	}
+	for _, c := range cases {
+		c := c
+		Convey("Given a sqljson.NullDecimal value pointer, and the JSON value "+c.in, t, func() {
+			ns := &sqljson.NullDecimal{}
+			Convey("When I Unmarshal it using UnmarshalJSON", func() {
+				err := ns.UnmarshalJSON([]byte(c.in))
+				Convey("Then I should get the exact decimal "+c.out, func() {
+					So(err, ShouldBeNil)
+					So(ns.Valid, ShouldBeTrue)
+					So(ns.Decimal, ShouldEqual, c.out)
+				})
+			})
+		})
+	}
+	Convey("Given a sqljson.NullDecimal value pointer, and a null JSON value", t, func() {
+		ns := &sqljson.NullDecimal{Decimal: "1", Valid: true}
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := ns.UnmarshalJSON([]byte(`null`))
+			Convey("Then I should get a Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	for _, in := range []string{`true`, `"abc"`, `"1.2.3"`, `"."`, `"1e99999"`, `"1e1001"`, `"1e-1001"`} {
+		in := in
+		Convey("Given a sqljson.NullDecimal value pointer, and the invalid JSON value "+in, t, func() {
+			ns := &sqljson.NullDecimal{}
+			Convey("When I Unmarshal it using UnmarshalJSON", func() {
+				err := ns.UnmarshalJSON([]byte(in))
+				Convey("Then I should get an error", func() {
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldContainSubstring, "invalid decimal")
+					So(ns.Valid, ShouldBeFalse)
+				})
+			})
+		})
+	}
+}
+
+func TestDecimalSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	Convey("Given a sql mock returning NUMERIC values in several driver types", t, func() {
+		mock.
+			ExpectQuery(`SELECT a, b, c, d, e FROM balances`).
+			WillReturnRows(sqlmock.NewRows([]string{"a", "b", "c", "d", "e"}).
+				AddRow([]byte("98765432109876543210.99"), "0.10", int64(5), 123.45, nil))
+		Convey("When I query a row and scan it", func() {
+			a, b, c, d, e := sqljson.NullDecimal{}, sqljson.NullDecimal{}, sqljson.NullDecimal{}, sqljson.NullDecimal{}, sqljson.NullDecimal{}
+			dbErr := db.QueryRow(`SELECT a, b, c, d, e FROM balances`).Scan(&a, &b, &c, &d, &e)
+			Convey("Then I should get exact decimals", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+				So(a.Decimal, ShouldEqual, "98765432109876543210.99")
+				So(b.Decimal, ShouldEqual, "0.10")
+				So(c.Decimal, ShouldEqual, "5")
+				So(d.Decimal, ShouldEqual, "123.45")
+				So(e.Valid, ShouldBeFalse)
+				So(a.Rat().FloatString(2), ShouldEqual, "98765432109876543210.99")
+				So(e.Rat(), ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a valid and a null sqljson.NullDecimal", t, func() {
+		a := sqljson.NullDecimal{Decimal: "98765432109876543210.99", Valid: true}
+		b := sqljson.NullDecimal{}
+		Convey("When I write them to the database", func() {
+			mock.
+				ExpectExec(`UPDATE balances SET a = \?, b = \?`).
+				WithArgs("98765432109876543210.99", nil).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			_, dbErr := db.Exec(`UPDATE balances SET a = ?, b = ?`, a, b)
+			Convey("Then they should be written without rounding", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestDecimalConstructors(t *testing.T) {
+	Convey("Given decimal texts, a pointer to one and a nil pointer", t, func() {
+		value := "12.50"
+		Convey("When I build sqljson.NullDecimal values from them", func() {
+			fromValue := sqljson.NewNullDecimal(value)
+			fromPtr := sqljson.NullDecimalFrom(&value)
+			fromNil := sqljson.NullDecimalFrom(nil)
+			fromEmpty := sqljson.NullDecimalFromZero("")
+			fromZero := sqljson.NullDecimalFromZero("-0.00")
+			fromNonZero := sqljson.NullDecimalFromZero("0.01")
+			Convey("Then only the nil pointer and the zero decimals should be NULL", func() {
+				So(fromValue.Valid, ShouldBeTrue)
+				So(fromValue.Decimal, ShouldEqual, value)
+				So(fromPtr.Valid, ShouldBeTrue)
+				So(fromNil.Valid, ShouldBeFalse)
+				So(fromEmpty.Valid, ShouldBeFalse)
+				So(fromZero.Valid, ShouldBeFalse)
+				So(fromNonZero.Valid, ShouldBeTrue)
+			})
+			Convey("Then ValueOrZero and ValueOr should fall back only when NULL", func() {
+				So(fromValue.ValueOrZero(), ShouldEqual, value)
+				So(fromNil.ValueOrZero(), ShouldEqual, "0")
+				So(fromNil.ValueOr("1.00"), ShouldEqual, "1.00")
+			})
+		})
+	})
+}
@@ -0,0 +1,471 @@
+package sqljson
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationFormat is a representation of durations.
+type DurationFormat int
+
+const (
+	// DurationISO8601 is an ISO 8601 duration, such as "PT1H30M", which
+	// PostgreSQL accepts for INTERVAL columns.
+	DurationISO8601 DurationFormat = iota
+	// DurationGo is a Go duration string, such as "1h30m0s".
+	DurationGo
+	// DurationInteger is an integer count of DurationUnit.
+	DurationInteger
+)
+
+// DurationUnit is the unit of durations given as integers, such as in
+// BIGINT columns holding seconds or milliseconds. Integer text is read in
+// this unit too. Integers are written, truncated, in this unit.
+var DurationUnit = time.Second
+
+// DurationJSONFormat is the format NullDuration uses in JSON, text and XML.
+// NullDuration reads every format whatever its value.
+var DurationJSONFormat = DurationISO8601
+
+// DurationStorage is the format NullDuration writes to the database:
+// DurationISO8601 for INTERVAL columns, or DurationInteger for integer
+// columns.
+var DurationStorage = DurationISO8601
+
+// Lengths of the calendar units of durations, as PostgreSQL counts them when
+// extracting the epoch of an interval.
+const (
+	durationDay   = 24 * time.Hour
+	durationMonth = 30 * durationDay
+	durationYear  = time.Duration(365.25 * float64(durationDay))
+)
+
+// durationUnits are the units of PostgreSQL interval text, singular and
+// plural.
+var durationUnits = map[string]time.Duration{
+	"year": durationYear, "years": durationYear,
+	"mon": durationMonth, "mons": durationMonth,
+	"month": durationMonth, "months": durationMonth,
+	"week": 7 * durationDay, "weeks": 7 * durationDay,
+	"day": durationDay, "days": durationDay,
+	"hour": time.Hour, "hours": time.Hour,
+	"min": time.Minute, "mins": time.Minute,
+	"minute": time.Minute, "minutes": time.Minute,
+	"sec": time.Second, "secs": time.Second,
+	"second": time.Second, "seconds": time.Second,
+}
+
+// scaleDuration returns value times unit, refusing results that overflow.
+func scaleDuration(value float64, unit time.Duration) (time.Duration, bool) {
+	d := value * float64(unit)
+	if math.IsNaN(d) || d >= math.MaxInt64 || d < math.MinInt64 {
+		return 0, false
+	}
+	return time.Duration(math.Round(d)), true
+}
+
+// parseISODuration parses an ISO 8601 duration, such as "P1DT2H30M" or
+// "-PT0.5S". Components may be fractional and signed, as PostgreSQL writes
+// them in its iso_8601 interval style. Years, months and weeks count as in
+// PostgreSQL.
+func parseISODuration(s string) (time.Duration, error) {
+	text, sign := s, 1.0
+	if strings.HasPrefix(text, "-") {
+		text, sign = text[1:], -1
+	}
+	if !strings.HasPrefix(text, "P") || len(text) < 3 {
+		return 0, fmt.Errorf("sqljson: invalid ISO 8601 duration %q", s)
+	}
+	text = text[1:]
+	var total time.Duration
+	inTime := false
+	for text != "" {
+		if text[0] == 'T' && !inTime {
+			inTime, text = true, text[1:]
+			continue
+		}
+		end := strings.IndexAny(text, "YMWDHS")
+		if end <= 0 {
+			return 0, fmt.Errorf("sqljson: invalid ISO 8601 duration %q", s)
+		}
+		value, err := strconv.ParseFloat(strings.Replace(text[:end], ",", ".", 1), 64)
+		if err != nil {
+			return 0, fmt.Errorf("sqljson: invalid ISO 8601 duration %q", s)
+		}
+		var unit time.Duration
+		switch designator := text[end]; {
+		case !inTime && designator == 'Y':
+			unit = durationYear
+		case !inTime && designator == 'M':
+			unit = durationMonth
+		case !inTime && designator == 'W':
+			unit = 7 * durationDay
+		case !inTime && designator == 'D':
+			unit = durationDay
+		case inTime && designator == 'H':
+			unit = time.Hour
+		case inTime && designator == 'M':
+			unit = time.Minute
+		case inTime && designator == 'S':
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("sqljson: invalid ISO 8601 duration %q", s)
+		}
+		d, ok := scaleDuration(sign*value, unit)
+		if !ok {
+			return 0, fmt.Errorf("sqljson: ISO 8601 duration %q out of range", s)
+		}
+		total += d
+		text = text[end+1:]
+	}
+	return total, nil
+}
+
+// formatISODuration formats d as an ISO 8601 duration in hours, minutes and
+// seconds, such as "PT36H0.5S".
+func formatISODuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	u := uint64(d)
+	if d < 0 {
+		b.WriteByte('-')
+		u = -u
+	}
+	b.WriteString("PT")
+	hours, minutes := u/uint64(time.Hour), u/uint64(time.Minute)%60
+	nanos := u % uint64(time.Minute)
+	if hours > 0 {
+		b.WriteString(strconv.FormatUint(hours, 10) + "H")
+	}
+	if minutes > 0 {
+		b.WriteString(strconv.FormatUint(minutes, 10) + "M")
+	}
+	if nanos > 0 {
+		seconds := strconv.FormatUint(nanos/uint64(time.Second), 10)
+		if frac := nanos % uint64(time.Second); frac > 0 {
+			seconds += strings.TrimRight(fmt.Sprintf(".%09d", frac), "0")
+		}
+		b.WriteString(seconds + "S")
+	}
+	return b.String()
+}
+
+// parseClockDuration parses the [-]hh:mm:ss[.fff] part of PostgreSQL interval
+// text.
+func parseClockDuration(s string) (time.Duration, bool) {
+	text, sign := s, 1.0
+	if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+		if text[0] == '-' {
+			sign = -1
+		}
+		text = text[1:]
+	}
+	parts := strings.Split(text, ":")
+	if len(parts) != 3 {
+		return 0, false
+	}
+	var total time.Duration
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		value, err := strconv.ParseFloat(parts[i], 64)
+		if err != nil || value < 0 || (i < 2 && strings.Contains(parts[i], ".")) {
+			return 0, false
+		}
+		d, ok := scaleDuration(sign*value, unit)
+		if !ok {
+			return 0, false
+		}
+		total += d
+	}
+	return total, true
+}
+
+// parsePostgresInterval parses PostgreSQL interval text in its default
+// output style, such as "1 year 2 mons 3 days 04:05:06.5" or "-01:30:00".
+// Years and months count as in PostgreSQL.
+func parsePostgresInterval(s string) (time.Duration, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("sqljson: invalid interval %q", s)
+	}
+	var total time.Duration
+	for i := 0; i < len(fields); i++ {
+		if d, ok := parseClockDuration(fields[i]); ok && i == len(fields)-1 {
+			total += d
+			continue
+		}
+		if i+1 >= len(fields) {
+			return 0, fmt.Errorf("sqljson: invalid interval %q", s)
+		}
+		value, err := strconv.ParseFloat(fields[i], 64)
+		unit, ok := durationUnits[strings.ToLower(fields[i+1])]
+		if err != nil || !ok {
+			return 0, fmt.Errorf("sqljson: invalid interval %q", s)
+		}
+		d, ok := scaleDuration(value, unit)
+		if !ok {
+			return 0, fmt.Errorf("sqljson: interval %q out of range", s)
+		}
+		total += d
+		i++
+	}
+	return total, nil
+}
+
+// parseDuration parses a duration as an integer count of DurationUnit, an
+// ISO 8601 duration, PostgreSQL interval text, or a Go duration string.
+func parseDuration(s string) (time.Duration, error) {
+	text := strings.TrimSpace(s)
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		if d, ok := scaleDuration(float64(i), DurationUnit); ok {
+			return d, nil
+		}
+		return 0, fmt.Errorf("sqljson: duration %q out of range", s)
+	}
+	if strings.HasPrefix(strings.TrimPrefix(text, "-"), "P") {
+		return parseISODuration(text)
+	}
+	if d, err := time.ParseDuration(text); err == nil {
+		return d, nil
+	}
+	if d, err := parsePostgresInterval(text); err == nil {
+		return d, nil
+	}
+	return 0, fmt.Errorf("sqljson: invalid duration %q", s)
+}
+
+// NullDuration is a nullable duration, as stored in PostgreSQL INTERVAL
+// columns or as an integer count of DurationUnit.
+type NullDuration struct {
+	Duration time.Duration
+	Valid    bool
+}
+
+// NewNullDuration //
+func NewNullDuration(value time.Duration) NullDuration {
+	return NullDuration{Duration: value, Valid: true}
+}
+
+// NullDurationFrom //
+func NullDurationFrom(value *time.Duration) NullDuration {
+	if value == nil {
+		return NullDuration{}
+	}
+	return NewNullDuration(*value)
+}
+
+// NullDurationFromZero //
+func NullDurationFromZero(value time.Duration) NullDuration {
+	if value == 0 {
+		return NullDuration{}
+	}
+	return NewNullDuration(value)
+}
+
+// NullDurationValidateValuer //
+func NullDurationValidateValuer(field reflect.Value) interface{} {
+	if nullDuration, ok := field.Interface().(NullDuration); ok {
+		return nullDuration.ValidateValue()
+	}
+	return nil
+}
+
+// ValidateValue returns the time.Duration, for the duration_min and
+// duration_max tags and for cross-field comparisons.
+func (ns NullDuration) ValidateValue() interface{} {
+	if ns.Valid {
+		return ns.Duration
+	}
+	return nil
+}
+
+// DurationPtrOrNil //
+func (ns NullDuration) DurationPtrOrNil() *time.Duration {
+	if ns.Valid {
+		d := ns.Duration
+		return &d
+	}
+	return nil
+}
+
+// ValueOrZero //
+func (ns NullDuration) ValueOrZero() time.Duration {
+	return ns.ValueOr(0)
+}
+
+// ValueOr //
+func (ns NullDuration) ValueOr(value time.Duration) time.Duration {
+	if ns.Valid {
+		return ns.Duration
+	}
+	return value
+}
+
+// IsZero //
+func (ns NullDuration) IsZero() bool {
+	return !ns.Valid
+}
+
+// Scan accepts integers and floats as counts of DurationUnit, and text in
+// any of the formats of parseDuration: PostgreSQL interval text, ISO 8601
+// durations, integers and Go duration strings.
+func (ns *NullDuration) Scan(value interface{}) error {
+	value = driverValue(value)
+	var d time.Duration
+	var err error
+	switch v := value.(type) {
+	case nil:
+		ns.Duration, ns.Valid = 0, false
+		return nil
+	case int64:
+		var ok bool
+		if d, ok = scaleDuration(float64(v), DurationUnit); !ok {
+			err = fmt.Errorf("sqljson: cannot scan %d into NullDuration: value out of range", v)
+		}
+	case float64:
+		var ok bool
+		if d, ok = scaleDuration(v, DurationUnit); !ok {
+			err = fmt.Errorf("sqljson: cannot scan %v into NullDuration: value out of range", v)
+		}
+	default:
+		text, ok := scanText(value)
+		if !ok {
+			return fmt.Errorf("sqljson: cannot scan %T into NullDuration", value)
+		}
+		d, err = parseDuration(text)
+	}
+	if err != nil {
+		return err
+	}
+	ns.Duration, ns.Valid = d, true
+	return nil
+}
+
+// Value writes the duration in the format of DurationStorage.
+func (ns NullDuration) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	switch DurationStorage {
+	case DurationInteger:
+		return int64(ns.Duration / DurationUnit), nil
+	case DurationGo:
+		return ns.Duration.String(), nil
+	}
+	return formatISODuration(ns.Duration), nil
+}
+
+// text returns the text of a non-NULL value, in DurationJSONFormat.
+func (ns NullDuration) text() string {
+	switch DurationJSONFormat {
+	case DurationGo:
+		return ns.Duration.String()
+	case DurationInteger:
+		return strconv.FormatInt(int64(ns.Duration/DurationUnit), 10)
+	}
+	return formatISODuration(ns.Duration)
+}
+
+// setText sets ns to the non-NULL value given as text.
+func (ns *NullDuration) setText(text string) error {
+	value, err := parseDuration(text)
+	if err != nil {
+		return err
+	}
+	ns.Duration, ns.Valid = value, true
+	return nil
+}
+
+// MarshalJSON marshals the duration as a string in DurationJSONFormat, or as
+// a number for DurationInteger.
+func (ns NullDuration) MarshalJSON() ([]byte, error) {
+	if !ns.Valid {
+		return json.Marshal(nil)
+	}
+	if DurationJSONFormat == DurationInteger {
+		return []byte(ns.text()), nil
+	}
+	return json.Marshal(ns.text())
+}
+
+// UnmarshalJSON accepts numbers, as counts of DurationUnit, and strings in
+// any format.
+func (ns *NullDuration) UnmarshalJSON(data []byte) error {
+	text := string(bytes.TrimSpace(data))
+	if text == "null" {
+		ns.Duration, ns.Valid = 0, false
+		return nil
+	}
+	if !strings.HasPrefix(text, `"`) {
+		var number float64
+		err := json.Unmarshal(data, &number)
+		if err != nil {
+			return err
+		}
+		d, ok := scaleDuration(number, DurationUnit)
+		if !ok {
+			return fmt.Errorf("sqljson: duration %s out of range", text)
+		}
+		ns.Duration, ns.Valid = d, true
+		return nil
+	}
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return err
+	}
+	return ns.setText(text)
+}
+
+// MarshalText //
+func (ns NullDuration) MarshalText() ([]byte, error) {
+	if !ns.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(ns.text()), nil
+}
+
+// UnmarshalText //
+func (ns *NullDuration) UnmarshalText(data []byte) error {
+	if isNullText(data) {
+		ns.Duration, ns.Valid = 0, false
+		return nil
+	}
+	return ns.setText(string(data))
+}
+
+// MarshalXML //
+func (ns NullDuration) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(e, start, ns.text(), ns.Valid)
+}
+
+// UnmarshalXML //
+func (ns *NullDuration) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	text, ok, err := unmarshalXMLText(d, start)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		ns.Duration, ns.Valid = 0, false
+		return nil
+	}
+	return ns.setText(text)
+}
+
+// MarshalXMLAttr //
+func (ns NullDuration) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, ns.text(), ns.Valid)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullDuration) UnmarshalXMLAttr(attr xml.Attr) error {
+	return ns.setText(attr.Value)
+}
@@ -0,0 +1,160 @@
+package sqljson_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rhaseven7h/sqljson"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDurationJSON(t *testing.T) {
+	type jsonStruct struct {
+		Window sqljson.NullDuration `json:"window"`
+		Grace  sqljson.NullDuration `json:"grace"`
+	}
+	Convey("Given JSON with an ISO 8601 duration and a null", t, func() {
+		data := []byte(`{"window":"PT36H30M0.5S","grace":null}`)
+		Convey("When I unmarshal it", func() {
+			var v jsonStruct
+			err := json.Unmarshal(data, &v)
+			Convey("Then I should get the duration and NULL", func() {
+				So(err, ShouldBeNil)
+				So(v.Window.Duration, ShouldEqual, 36*time.Hour+30*time.Minute+500*time.Millisecond)
+				So(v.Grace.Valid, ShouldBeFalse)
+			})
+			Convey("And marshaling it back should give the same JSON", func() {
+				out, errMarshal := json.Marshal(v)
+				So(errMarshal, ShouldBeNil)
+				So(string(out), ShouldEqual, string(data))
+			})
+			Convey("And with DurationGo, it should marshal as a Go duration", func() {
+				sqljson.DurationJSONFormat = sqljson.DurationGo
+				defer func() { sqljson.DurationJSONFormat = sqljson.DurationISO8601 }()
+				out, errMarshal := json.Marshal(v)
+				So(errMarshal, ShouldBeNil)
+				So(string(out), ShouldEqual, `{"window":"36h30m0.5s","grace":null}`)
+			})
+		})
+	})
+	Convey("Given JSON with durations in every format", t, func() {
+		data := []byte(`[90, "1h30m", "P1DT-30M", "-PT1M", "1 day 02:00:00"]`)
+		Convey("When I unmarshal it", func() {
+			var v []sqljson.NullDuration
+			err := json.Unmarshal(data, &v)
+			Convey("Then I should get the durations", func() {
+				So(err, ShouldBeNil)
+				So(v[0].Duration, ShouldEqual, 90*time.Second)
+				So(v[1].Duration, ShouldEqual, 90*time.Minute)
+				So(v[2].Duration, ShouldEqual, 23*time.Hour+30*time.Minute)
+				So(v[3].Duration, ShouldEqual, -time.Minute)
+				So(v[4].Duration, ShouldEqual, 26*time.Hour)
+			})
+		})
+	})
+	Convey("Given JSON with invalid durations", t, func() {
+		Convey("When I unmarshal them", func() {
+			var v jsonStruct
+			errISO := json.Unmarshal([]byte(`{"window":"PT1H2D"}`), &v)
+			errText := json.Unmarshal([]byte(`{"window":"soon"}`), &v)
+			Convey("Then I should get errors", func() {
+				So(errISO, ShouldNotBeNil)
+				So(errText, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestDurationSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	Convey("Given a sql mock returning intervals, ISO 8601 text, integers and NULL", t, func() {
+		mock.
+			ExpectQuery(`SELECT a, b, c, d, e, f FROM slas`).
+			WillReturnRows(sqlmock.NewRows([]string{"a", "b", "c", "d", "e", "f"}).
+				AddRow([]byte("1 year 2 mons 3 days 04:05:06.5"), "-01:30:00", []byte("P1W"), int64(90), []byte("90"), nil))
+		Convey("When I query a row and scan it", func() {
+			var a, b, c, d, e, f sqljson.NullDuration
+			dbErr := db.QueryRow(`SELECT a, b, c, d, e, f FROM slas`).Scan(&a, &b, &c, &d, &e, &f)
+			Convey("Then I should get the durations, counting months as 30 days", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+				day := 24 * time.Hour
+				So(a.Duration, ShouldEqual, time.Duration(365.25*float64(day))+63*day+4*time.Hour+5*time.Minute+6500*time.Millisecond)
+				So(b.Duration, ShouldEqual, -90*time.Minute)
+				So(c.Duration, ShouldEqual, 7*day)
+				So(d.Duration, ShouldEqual, 90*time.Second)
+				So(e.Duration, ShouldEqual, 90*time.Second)
+				So(f.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a sql mock returning milliseconds", t, func() {
+		mock.
+			ExpectQuery(`SELECT a FROM slas`).
+			WillReturnRows(sqlmock.NewRows([]string{"a"}).AddRow(int64(1500)))
+		Convey("When I scan it with DurationUnit set to time.Millisecond", func() {
+			sqljson.DurationUnit = time.Millisecond
+			defer func() { sqljson.DurationUnit = time.Second }()
+			var a sqljson.NullDuration
+			dbErr := db.QueryRow(`SELECT a FROM slas`).Scan(&a)
+			Convey("Then I should get the duration", func() {
+				So(dbErr, ShouldBeNil)
+				So(a.Duration, ShouldEqual, 1500*time.Millisecond)
+			})
+		})
+	})
+	Convey("Given a valid sqljson.NullDuration", t, func() {
+		ns := sqljson.NewNullDuration(90*time.Minute + 30*time.Second)
+		Convey("When I get its value", func() {
+			value, err := ns.Value()
+			Convey("Then I should get ISO 8601 text", func() {
+				So(err, ShouldBeNil)
+				So(value, ShouldEqual, "PT1H30M30S")
+			})
+			Convey("And with DurationInteger storage, I should get seconds", func() {
+				sqljson.DurationStorage = sqljson.DurationInteger
+				defer func() { sqljson.DurationStorage = sqljson.DurationISO8601 }()
+				value, err := ns.Value()
+				So(err, ShouldBeNil)
+				So(value, ShouldEqual, int64(5430))
+			})
+		})
+	})
+}
+
+func TestDurationValidator(t *testing.T) {
+	type validatorStruct struct {
+		Window sqljson.NullDuration `json:"window" validate:"required,duration_min=15m,duration_max=24h"`
+		Grace  sqljson.NullDuration `json:"grace" validate:"omitempty,ltfield=Window"`
+	}
+	Convey("Given a validator from NewValidator", t, func() {
+		validate := sqljson.NewValidator()
+		Convey("When I validate durations in and out of range", func() {
+			errIn := validate.Struct(&validatorStruct{Window: sqljson.NewNullDuration(time.Hour)})
+			errShort := validate.Struct(&validatorStruct{Window: sqljson.NewNullDuration(time.Minute)})
+			errLong := validate.Struct(&validatorStruct{Window: sqljson.NewNullDuration(48 * time.Hour)})
+			errNull := validate.Struct(&validatorStruct{})
+			Convey("Then I should get appropriate results", func() {
+				So(errIn, ShouldBeNil)
+				So(validationTags(errShort), ShouldResemble, map[string]string{"window": "duration_min"})
+				So(validationTags(errLong), ShouldResemble, map[string]string{"window": "duration_max"})
+				So(validationTags(errNull), ShouldResemble, map[string]string{"window": "required"})
+			})
+			Convey("And ltfield should compare durations", func() {
+				errShorter := validate.Struct(&validatorStruct{Window: sqljson.NewNullDuration(time.Hour), Grace: sqljson.NewNullDuration(time.Minute)})
+				errLonger := validate.Struct(&validatorStruct{Window: sqljson.NewNullDuration(time.Hour), Grace: sqljson.NewNullDuration(2 * time.Hour)})
+				So(errShorter, ShouldBeNil)
+				So(validationTags(errLonger), ShouldResemble, map[string]string{"grace": "ltfield"})
+			})
+		})
+	})
+}
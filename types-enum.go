@@ -0,0 +1,280 @@
+package sqljson
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// UnknownEnumError is returned when a value is not one of the values
+// registered for its enum type.
+type UnknownEnumError struct {
+	Type  reflect.Type
+	Value interface{}
+}
+
+func (e *UnknownEnumError) Error() string {
+	return fmt.Sprintf("sqljson: unknown %s value %#v", e.Type, e.Value)
+}
+
+// enumValues are the values registered for an enum type, by name and by
+// value, along with a NullEnum of that type for RegisterValidator.
+type enumValues struct {
+	names    map[string]interface{}
+	values   map[interface{}]string
+	nullEnum interface{}
+}
+
+var (
+	enumsMu sync.RWMutex
+	enums   = map[reflect.Type]*enumValues{}
+)
+
+// RegisterEnum registers the allowed values of the enum type T, keyed by
+// their symbolic names, for use by NullEnum[T]. Registering T again
+// replaces its values. Validators set up afterwards by RegisterValidator or
+// NewValidator have NullEnum[T] registered.
+func RegisterEnum[T comparable](names map[string]T) {
+	values := &enumValues{names: map[string]interface{}{}, values: map[interface{}]string{}, nullEnum: NullEnum[T]{}}
+	for name, value := range names {
+		values.names[name] = value
+		values.values[value] = name
+	}
+	enumsMu.Lock()
+	defer enumsMu.Unlock()
+	enums[enumType[T]()] = values
+}
+
+// EnumNames returns the registered names of the enum type T, sorted.
+func EnumNames[T comparable]() []string {
+	values := enumFor[T]()
+	names := []string{}
+	if values != nil {
+		for name := range values.names {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// enumFor returns the values registered for T, or nil.
+func enumFor[T comparable]() *enumValues {
+	enumsMu.RLock()
+	defer enumsMu.RUnlock()
+	return enums[enumType[T]()]
+}
+
+// enumValidatorTypes returns a NullEnum of every registered enum type.
+func enumValidatorTypes() []interface{} {
+	enumsMu.RLock()
+	defer enumsMu.RUnlock()
+	types := []interface{}{}
+	for _, values := range enums {
+		types = append(types, values.nullEnum)
+	}
+	return types
+}
+
+// enumType returns the reflect.Type of T.
+func enumType[T comparable]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// NullEnum is a nullable value of an enum type T, such as a string or small
+// int type with a known set of values registered with RegisterEnum. It
+// marshals to JSON, text and XML as the symbolic name of its value, and is
+// written to the database as the value itself, so a string enum stores its
+// names and an int enum its integer codes.
+type NullEnum[T comparable] struct {
+	Null[T]
+}
+
+// NewNullEnum returns a non-NULL NullEnum holding value.
+func NewNullEnum[T comparable](value T) NullEnum[T] {
+	return NullEnum[T]{Null: NewNull(value)}
+}
+
+// NullEnumFrom returns a NullEnum holding the value pointed to, or NULL when
+// value is nil.
+func NullEnumFrom[T comparable](value *T) NullEnum[T] {
+	return NullEnum[T]{Null: NullFrom(value)}
+}
+
+// NullEnumFromZero returns a NullEnum holding value, or NULL when value is
+// the zero value of T.
+func NullEnumFromZero[T comparable](value T) NullEnum[T] {
+	return NullEnum[T]{Null: NullFromZero(value)}
+}
+
+// Name returns the symbolic name of the value, or an *UnknownEnumError when
+// it is not registered.
+func (n NullEnum[T]) Name() (string, error) {
+	if values := enumFor[T](); values != nil {
+		if name, ok := values.values[n.V]; ok {
+			return name, nil
+		}
+	}
+	return "", &UnknownEnumError{Type: enumType[T](), Value: n.V}
+}
+
+// setName sets n to the non-NULL value named name.
+func (n *NullEnum[T]) setName(name string) error {
+	if values := enumFor[T](); values != nil {
+		if value, ok := values.names[name]; ok {
+			n.V, n.Valid = value.(T), true
+			return nil
+		}
+	}
+	return &UnknownEnumError{Type: enumType[T](), Value: name}
+}
+
+// ValidateValue returns the symbolic name of the value, so that oneof can
+// list allowed names.
+func (n NullEnum[T]) ValidateValue() interface{} {
+	if !n.Valid {
+		return nil
+	}
+	name, err := n.Name()
+	if err != nil {
+		return fmt.Sprint(n.V)
+	}
+	return name
+}
+
+// Scan accepts both the registered values, such as integer codes, and their
+// symbolic names. Other values return an *UnknownEnumError.
+func (n *NullEnum[T]) Scan(value interface{}) error {
+	if value == nil {
+		var zero T
+		n.V, n.Valid = zero, false
+		return nil
+	}
+	values := enumFor[T]()
+	if values != nil {
+		var null sql.Null[T]
+		if null.Scan(value) == nil {
+			if _, ok := values.values[null.V]; ok {
+				n.V, n.Valid = null.V, true
+				return nil
+			}
+		}
+		if text, ok := scanText(value); ok {
+			if _, ok := values.names[text]; ok {
+				return n.setName(text)
+			}
+		}
+	}
+	return &UnknownEnumError{Type: enumType[T](), Value: driverValue(value)}
+}
+
+// Value writes the value itself, refusing values that are not registered.
+func (n NullEnum[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	if _, err := n.Name(); err != nil {
+		return nil, err
+	}
+	return n.Null.Value()
+}
+
+// MarshalJSON marshals the symbolic name of the value.
+func (n NullEnum[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return json.Marshal(nil)
+	}
+	name, err := n.Name()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(name)
+}
+
+// UnmarshalJSON accepts a symbolic name, returning an *UnknownEnumError for
+// names that are not registered.
+func (n *NullEnum[T]) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		var zero T
+		n.V, n.Valid = zero, false
+		return nil
+	}
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+	return n.setName(name)
+}
+
+// MarshalText //
+func (n NullEnum[T]) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte(NullText), nil
+	}
+	name, err := n.Name()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText //
+func (n *NullEnum[T]) UnmarshalText(data []byte) error {
+	if isNullText(data) {
+		var zero T
+		n.V, n.Valid = zero, false
+		return nil
+	}
+	return n.setName(string(data))
+}
+
+// MarshalXML //
+func (n NullEnum[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !n.Valid {
+		return marshalXMLNull(e, start)
+	}
+	name, err := n.Name()
+	if err != nil {
+		return err
+	}
+	return marshalXMLText(e, start, name, true)
+}
+
+// UnmarshalXML //
+func (n *NullEnum[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	text, ok, err := unmarshalXMLText(d, start)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		var zero T
+		n.V, n.Valid = zero, false
+		return nil
+	}
+	return n.setName(strings.TrimSpace(text))
+}
+
+// MarshalXMLAttr //
+func (n NullEnum[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !n.Valid {
+		return xml.Attr{}, nil
+	}
+	text, err := n.Name()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return marshalXMLAttrText(name, text, true)
+}
+
+// UnmarshalXMLAttr //
+func (n *NullEnum[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.setName(strings.TrimSpace(attr.Value))
+}
@@ -0,0 +1,151 @@
+package sqljson_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	validator "gopkg.in/go-playground/validator.v9"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type enumStatus string
+
+type enumPriority int
+
+func init() {
+	sqljson.RegisterEnum(map[string]enumStatus{"active": "active", "archived": "archived"})
+	sqljson.RegisterEnum(map[string]enumPriority{"low": 1, "normal": 2, "high": 3})
+}
+
+type enumTicket struct {
+	Status   sqljson.NullEnum[enumStatus]   `json:"status" validate:"required,oneof=active"`
+	Priority sqljson.NullEnum[enumPriority] `json:"priority" validate:"omitempty,oneof=normal high"`
+}
+
+func TestEnumJSON(t *testing.T) {
+	Convey("Given JSON with symbolic enum names", t, func() {
+		data := []byte(`{"status":"archived","priority":"high"}`)
+		Convey("When I unmarshal it", func() {
+			var ticket enumTicket
+			err := json.Unmarshal(data, &ticket)
+			Convey("Then I should get the registered values", func() {
+				So(err, ShouldBeNil)
+				So(ticket.Status.V, ShouldEqual, enumStatus("archived"))
+				So(ticket.Priority.V, ShouldEqual, enumPriority(3))
+			})
+			Convey("And marshaling it back should write the names", func() {
+				out, errMarshal := json.Marshal(ticket)
+				So(errMarshal, ShouldBeNil)
+				So(string(out), ShouldEqual, string(data))
+			})
+		})
+	})
+	Convey("Given JSON with an unknown enum name", t, func() {
+		Convey("When I unmarshal it", func() {
+			var ticket enumTicket
+			err := json.Unmarshal([]byte(`{"priority":"urgent"}`), &ticket)
+			Convey("Then I should get an *UnknownEnumError", func() {
+				var enumErr *sqljson.UnknownEnumError
+				So(errors.As(err, &enumErr), ShouldBeTrue)
+				So(enumErr.Value, ShouldEqual, "urgent")
+				So(err.Error(), ShouldEqual, `sqljson: unknown sqljson_test.enumPriority value "urgent"`)
+			})
+		})
+	})
+	Convey("Given a NullEnum holding an unregistered value", t, func() {
+		Convey("When I marshal it", func() {
+			_, err := json.Marshal(sqljson.NewNullEnum(enumPriority(9)))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+	Convey("Given the registered enum types", t, func() {
+		Convey("Then EnumNames should list their names", func() {
+			So(sqljson.EnumNames[enumPriority](), ShouldResemble, []string{"high", "low", "normal"})
+		})
+	})
+}
+
+func TestEnumSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	Convey("Given a sql mock returning enum codes and names", t, func() {
+		mock.
+			ExpectQuery(`SELECT a, b, c, d FROM tickets`).
+			WillReturnRows(sqlmock.NewRows([]string{"a", "b", "c", "d"}).
+				AddRow([]byte("active"), int64(2), []byte("high"), nil))
+		Convey("When I query a row and scan it", func() {
+			var a sqljson.NullEnum[enumStatus]
+			var b, c, d sqljson.NullEnum[enumPriority]
+			dbErr := db.QueryRow(`SELECT a, b, c, d FROM tickets`).Scan(&a, &b, &c, &d)
+			Convey("Then I should get the registered values", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+				So(a.V, ShouldEqual, enumStatus("active"))
+				So(b.V, ShouldEqual, enumPriority(2))
+				So(c.V, ShouldEqual, enumPriority(3))
+				So(d.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a sql mock returning an unknown enum code", t, func() {
+		mock.
+			ExpectQuery(`SELECT a FROM tickets`).
+			WillReturnRows(sqlmock.NewRows([]string{"a"}).AddRow(int64(7)))
+		Convey("When I query a row and scan it", func() {
+			var a sqljson.NullEnum[enumPriority]
+			dbErr := db.QueryRow(`SELECT a FROM tickets`).Scan(&a)
+			Convey("Then I should get an *UnknownEnumError", func() {
+				var enumErr *sqljson.UnknownEnumError
+				So(errors.As(dbErr, &enumErr), ShouldBeTrue)
+				So(enumErr.Value, ShouldEqual, int64(7))
+			})
+		})
+	})
+	Convey("Given string and int enums", t, func() {
+		Convey("When I get their values", func() {
+			status, errStatus := sqljson.NewNullEnum(enumStatus("active")).Value()
+			priority, errPriority := sqljson.NewNullEnum(enumPriority(3)).Value()
+			_, errUnknown := sqljson.NewNullEnum(enumPriority(9)).Value()
+			Convey("Then names and codes should be written, and unknown values refused", func() {
+				So(errStatus, ShouldBeNil)
+				So(status, ShouldEqual, "active")
+				So(errPriority, ShouldBeNil)
+				So(priority, ShouldEqual, int64(3))
+				So(errUnknown, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestEnumValidator(t *testing.T) {
+	Convey("Given a validator from NewValidator", t, func() {
+		validate := sqljson.NewValidator()
+		Convey("When I validate enums in and out of their oneof lists", func() {
+			errIn := validate.Struct(&enumTicket{
+				Status:   sqljson.NewNullEnum(enumStatus("active")),
+				Priority: sqljson.NewNullEnum(enumPriority(2)),
+			})
+			errOut := validate.Struct(&enumTicket{
+				Status:   sqljson.NewNullEnum(enumStatus("archived")),
+				Priority: sqljson.NewNullEnum(enumPriority(1)),
+			})
+			errNull := validate.Struct(&enumTicket{})
+			Convey("Then oneof should check their names", func() {
+				So(errIn, ShouldBeNil)
+				So(validationTags(errOut), ShouldResemble, map[string]string{"status": "oneof", "priority": "oneof"})
+				So(errNull.(validator.ValidationErrors)[0].Tag(), ShouldEqual, "required")
+			})
+		})
+	})
+}
@@ -2,10 +2,19 @@ package sqljson
 
 import (
 	"database/sql"
-	"encoding/json"
+	"database/sql/driver"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
 	"reflect"
+	"strconv"
 )
 
+// ErrNonFiniteFloat is wrapped by the error returned when writing a NaN or
+// infinite float to the database, which most databases refuse.
+var ErrNonFiniteFloat = errors.New("sqljson: cannot write a NaN or infinite float to the database")
+
 // NullFloat64 //
 type NullFloat64 struct {
 	sql.NullFloat64
@@ -14,43 +23,160 @@ type NullFloat64 struct {
 // NullFloat64ValidateValuer //
 func NullFloat64ValidateValuer(field reflect.Value) interface{} {
 	if nullFloat64, ok := field.Interface().(NullFloat64); ok {
-		if nullFloat64.Valid {
-			return nullFloat64.Float64
-		}
+		return nullFloat64.ValidateValue()
 	}
 	return nil
 }
 
+func (ns NullFloat64) null() Null[float64] {
+	return newNull(ns.Float64, ns.Valid)
+}
+
+func nullFloat64Of(n Null[float64]) NullFloat64 {
+	return NullFloat64{NullFloat64: sql.NullFloat64{Float64: n.V, Valid: n.Valid}}
+}
+
+// NewNullFloat64 //
+func NewNullFloat64(value float64) NullFloat64 {
+	return nullFloat64Of(NewNull(value))
+}
+
+// NullFloat64From //
+func NullFloat64From(value *float64) NullFloat64 {
+	return nullFloat64Of(NullFrom(value))
+}
+
+// NullFloat64FromZero //
+func NullFloat64FromZero(value float64) NullFloat64 {
+	return nullFloat64Of(NullFromZero(value))
+}
+
+// ValidateValue //
+func (ns NullFloat64) ValidateValue() interface{} {
+	return ns.null().ValidateValue()
+}
+
 // Float64PtrOrNil //
 func (ns NullFloat64) Float64PtrOrNil() *float64 {
-	if ns.Valid {
-		s := ns.Float64
-		return &s
+	return ns.null().PtrOrNil()
+}
+
+// ValueOrZero //
+func (ns NullFloat64) ValueOrZero() float64 {
+	return ns.null().ValueOrZero()
+}
+
+// ValueOr //
+func (ns NullFloat64) ValueOr(value float64) float64 {
+	return ns.null().ValueOr(value)
+}
+
+// IsZero //
+func (ns NullFloat64) IsZero() bool {
+	return !ns.Valid
+}
+
+// Scan accepts floats, integers and decimal texts, as drivers return
+// NUMERIC columns as []byte.
+func (ns *NullFloat64) Scan(value interface{}) error {
+	if value == nil {
+		ns.Float64, ns.Valid = 0.0, false
+		return nil
 	}
+	v, err := scanFloat(value, "NullFloat64")
+	if err != nil {
+		return err
+	}
+	ns.Float64, ns.Valid = v, true
 	return nil
 }
 
+// Value //
+func (ns NullFloat64) Value() (driver.Value, error) {
+	return ns.null().Value()
+}
+
 // MarshalJSON //
 func (ns NullFloat64) MarshalJSON() ([]byte, error) {
-	if ns.Valid {
-		return json.Marshal(ns.Float64)
-	}
-	return json.Marshal(nil)
+	return ns.null().MarshalJSON()
 }
 
 // UnmarshalJSON //
 func (ns *NullFloat64) UnmarshalJSON(data []byte) error {
-	value := new(float64)
-	err := json.Unmarshal(data, &value)
+	n := Null[float64]{}
+	err := n.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	ns.Float64 = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXML //
+func (ns NullFloat64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return ns.null().MarshalXML(e, start)
+}
+
+// UnmarshalXML //
+func (ns *NullFloat64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	n := Null[float64]{}
+	err := n.UnmarshalXML(d, start)
+	if err != nil {
+		return err
+	}
+	ns.Float64 = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXMLAttr //
+func (ns NullFloat64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return ns.null().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullFloat64) UnmarshalXMLAttr(attr xml.Attr) error {
+	n := Null[float64]{}
+	err := n.UnmarshalXMLAttr(attr)
 	if err != nil {
 		return err
 	}
-	if value != nil {
-		ns.Float64 = *value
-		ns.Valid = true
-	} else {
+	ns.Float64 = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// text returns the text of a non-NULL value.
+func (ns NullFloat64) text() string {
+	return strconv.FormatFloat(ns.Float64, 'g', -1, 64)
+}
+
+// setText sets ns to the non-NULL value given as text.
+func (ns *NullFloat64) setText(text string) error {
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("sqljson: cannot unmarshal text %q into a float64", text)
+	}
+	ns.Float64 = value
+	ns.Valid = true
+	return nil
+}
+
+// MarshalText //
+func (ns NullFloat64) MarshalText() ([]byte, error) {
+	if !ns.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(ns.text()), nil
+}
+
+// UnmarshalText //
+func (ns *NullFloat64) UnmarshalText(data []byte) error {
+	if isNullText(data) {
 		ns.Float64 = 0.0
 		ns.Valid = false
+		return nil
 	}
-	return nil
+	return ns.setText(string(data))
 }
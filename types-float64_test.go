@@ -2,6 +2,8 @@ package sqljson_test
 
 import (
 	"database/sql"
+	"errors"
+	"math"
 	"reflect"
 	"testing"
 
@@ -228,3 +230,99 @@ func TestFloat64UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestFloat64Constructors(t *testing.T) {
+	Convey("Given a float64 value, a pointer to it, a nil pointer and the zero value", t, func() {
+		value := 123.45
+		Convey("When I build sqljson.NullFloat64 values from them", func() {
+			fromValue := sqljson.NewNullFloat64(value)
+			fromPtr := sqljson.NullFloat64From(&value)
+			fromNil := sqljson.NullFloat64From(nil)
+			fromZero := sqljson.NullFloat64FromZero(0.0)
+			fromNonZero := sqljson.NullFloat64FromZero(value)
+			Convey("Then only the nil pointer and the zero value should be NULL", func() {
+				So(fromValue.Valid, ShouldBeTrue)
+				So(fromValue.Float64, ShouldEqual, value)
+				So(fromPtr.Valid, ShouldBeTrue)
+				So(fromPtr.Float64, ShouldEqual, value)
+				So(fromNil.Valid, ShouldBeFalse)
+				So(fromZero.Valid, ShouldBeFalse)
+				So(fromNonZero.Valid, ShouldBeTrue)
+			})
+			Convey("Then ValueOrZero and ValueOr should fall back only when NULL", func() {
+				So(fromValue.ValueOrZero(), ShouldEqual, value)
+				So(fromValue.ValueOr(-1.5), ShouldEqual, value)
+				So(fromNil.ValueOrZero(), ShouldEqual, 0.0)
+				So(fromNil.ValueOr(-1.5), ShouldEqual, -1.5)
+			})
+		})
+	})
+}
+
+func TestFloat64MarshalText(t *testing.T) {
+	Convey("Given a non-null and a null sqljson.NullFloat64 value", t, func() {
+		valueIn := sqljson.NewNullFloat64(12.5)
+		null := sqljson.NullFloat64{}
+		Convey("When I marshal them as text", func() {
+			b, err := valueIn.MarshalText()
+			bNull, errNull := null.MarshalText()
+			Convey("Then I should get the value and sqljson.NullText", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "12.5")
+				So(errNull, ShouldBeNil)
+				So(string(bNull), ShouldEqual, sqljson.NullText)
+			})
+		})
+	})
+}
+
+func TestFloat64UnmarshalText(t *testing.T) {
+	Convey("Given a sqljson.NullFloat64 value pointer, and a text value", t, func() {
+		ns := &sqljson.NullFloat64{}
+		Convey("When I Unmarshal it using UnmarshalText", func() {
+			err := ns.UnmarshalText([]byte("12.5"))
+			Convey("Then I should get a not-Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeTrue)
+				So(ns.Float64, ShouldEqual, 12.5)
+			})
+		})
+		Convey("When I Unmarshal sqljson.NullText using UnmarshalText", func() {
+			_ = ns.UnmarshalText([]byte("12.5"))
+			err := ns.UnmarshalText([]byte(sqljson.NullText))
+			Convey("Then I should get a Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+		Convey("When I Unmarshal an invalid text using UnmarshalText", func() {
+			err := ns.UnmarshalText([]byte("NaN"))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "cannot unmarshal text")
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestFloat64Value(t *testing.T) {
+	Convey("Given a finite, a NaN and a null sqljson.NullFloat64 value", t, func() {
+		finite := sqljson.NewNullFloat64(-1.25)
+		nan := sqljson.NewNullFloat64(math.NaN())
+		null := sqljson.NullFloat64{}
+		Convey("When I get their driver values", func() {
+			v, err := finite.Value()
+			_, errNaN := nan.Value()
+			vNull, errNull := null.Value()
+			Convey("Then the NaN should be rejected with ErrNonFiniteFloat", func() {
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, -1.25)
+				So(errors.Is(errNaN, sqljson.ErrNonFiniteFloat), ShouldBeTrue)
+				So(errNaN.Error(), ShouldEndWith, ": NaN")
+				So(errNull, ShouldBeNil)
+				So(vNull, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,141 @@
+package sqljson
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/xml"
+	"math"
+	"reflect"
+)
+
+// NullInt16 //
+type NullInt16 struct {
+	sql.NullInt16
+}
+
+// NullInt16ValidateValuer //
+func NullInt16ValidateValuer(field reflect.Value) interface{} {
+	if nullInt16, ok := field.Interface().(NullInt16); ok {
+		return nullInt16.ValidateValue()
+	}
+	return nil
+}
+
+func (ns NullInt16) null() Null[int16] {
+	return newNull(ns.Int16, ns.Valid)
+}
+
+func nullInt16Of(n Null[int16]) NullInt16 {
+	return NullInt16{NullInt16: sql.NullInt16{Int16: n.V, Valid: n.Valid}}
+}
+
+// NewNullInt16 //
+func NewNullInt16(value int16) NullInt16 {
+	return nullInt16Of(NewNull(value))
+}
+
+// NullInt16From //
+func NullInt16From(value *int16) NullInt16 {
+	return nullInt16Of(NullFrom(value))
+}
+
+// NullInt16FromZero //
+func NullInt16FromZero(value int16) NullInt16 {
+	return nullInt16Of(NullFromZero(value))
+}
+
+// ValidateValue //
+func (ns NullInt16) ValidateValue() interface{} {
+	return ns.null().ValidateValue()
+}
+
+// Int16PtrOrNil //
+func (ns NullInt16) Int16PtrOrNil() *int16 {
+	return ns.null().PtrOrNil()
+}
+
+// ValueOrZero //
+func (ns NullInt16) ValueOrZero() int16 {
+	return ns.null().ValueOrZero()
+}
+
+// ValueOr //
+func (ns NullInt16) ValueOr(value int16) int16 {
+	return ns.null().ValueOr(value)
+}
+
+// IsZero //
+func (ns NullInt16) IsZero() bool {
+	return !ns.Valid
+}
+
+// Scan accepts the same representations as NullInt64.Scan, within the
+// range of an int16.
+func (ns *NullInt16) Scan(value interface{}) error {
+	if value == nil {
+		ns.Int16, ns.Valid = 0, false
+		return nil
+	}
+	v, err := scanInt(value, math.MinInt16, math.MaxInt16, "NullInt16")
+	if err != nil {
+		return err
+	}
+	ns.Int16, ns.Valid = int16(v), true
+	return nil
+}
+
+// Value //
+func (ns NullInt16) Value() (driver.Value, error) {
+	return ns.null().Value()
+}
+
+// MarshalJSON //
+func (ns NullInt16) MarshalJSON() ([]byte, error) {
+	return ns.null().MarshalJSON()
+}
+
+// UnmarshalJSON //
+func (ns *NullInt16) UnmarshalJSON(data []byte) error {
+	n := Null[int16]{}
+	err := n.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	ns.Int16 = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXML //
+func (ns NullInt16) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return ns.null().MarshalXML(e, start)
+}
+
+// UnmarshalXML //
+func (ns *NullInt16) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	n := Null[int16]{}
+	err := n.UnmarshalXML(d, start)
+	if err != nil {
+		return err
+	}
+	ns.Int16 = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXMLAttr //
+func (ns NullInt16) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return ns.null().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullInt16) UnmarshalXMLAttr(attr xml.Attr) error {
+	n := Null[int16]{}
+	err := n.UnmarshalXMLAttr(attr)
+	if err != nil {
+		return err
+	}
+	ns.Int16 = n.V
+	ns.Valid = n.Valid
+	return nil
+}
@@ -0,0 +1,160 @@
+package sqljson_test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNullInt16ValidateValuer(t *testing.T) {
+	Convey("Given a non-null sqljson.NullInt16 value", t, func() {
+		ov := sqljson.NullInt16{
+			NullInt16: sql.NullInt16{
+				Int16: -32768,
+				Valid: true,
+			},
+		}
+		Convey("When evaluated", func() {
+			ivOut := sqljson.NullInt16ValidateValuer(reflect.ValueOf(ov))
+			Convey("Then we should get the value", func() {
+				nv, ok := ivOut.(int16)
+				So(ok, ShouldBeTrue)
+				So(nv, ShouldEqual, -32768)
+			})
+		})
+	})
+	Convey("Given a null sqljson.NullInt16 value", t, func() {
+		ov := sqljson.NullInt16{}
+		Convey("When evaluated", func() {
+			ivOut := sqljson.NullInt16ValidateValuer(reflect.ValueOf(ov))
+			Convey("Then we should get nil", func() {
+				So(ivOut, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestInt16PtrOrNil(t *testing.T) {
+	Convey("Given a valid non-Null NullInt16 value", t, func() {
+		value := sqljson.NullInt16{NullInt16: sql.NullInt16{Int16: -32768, Valid: true}}
+		Convey("When we get the Int16PtrOrNil", func() {
+			res := value.Int16PtrOrNil()
+			Convey("Then we get the value", func() {
+				So(res, ShouldNotBeNil)
+				So(*res, ShouldEqual, -32768)
+			})
+		})
+	})
+	Convey("Given a valid null NullInt16 value", t, func() {
+		value := sqljson.NullInt16{}
+		Convey("When we get the Int16PtrOrNil", func() {
+			res := value.Int16PtrOrNil()
+			Convey("Then we get nil", func() {
+				So(res, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestInt16MarshalJSON(t *testing.T) {
+	Convey("Given a non-null sqljson.NullInt16 value", t, func() {
+		valueIn := sqljson.NullInt16{NullInt16: sql.NullInt16{Int16: -32768, Valid: true}}
+		Convey("When I marshal it", func() {
+			b, err := valueIn.MarshalJSON()
+			Convey("Then I should get the number", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `-32768`)
+			})
+		})
+	})
+	Convey("Given a null sqljson.NullInt16 value", t, func() {
+		valueIn := sqljson.NullInt16{}
+		Convey("When I marshal it", func() {
+			b, err := valueIn.MarshalJSON()
+			Convey("Then I should get null", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `null`)
+			})
+		})
+	})
+}
+
+func TestInt16UnmarshalJSON(t *testing.T) {
+	Convey("Given a sqljson.NullInt16 value pointer, and a number JSON value", t, func() {
+		ns := &sqljson.NullInt16{}
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := ns.UnmarshalJSON([]byte(`-32768`))
+			Convey("Then I should get a not-Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeTrue)
+				So(ns.Int16, ShouldEqual, -32768)
+			})
+		})
+	})
+	Convey("Given a sqljson.NullInt16 value pointer, and a null JSON value", t, func() {
+		ns := &sqljson.NullInt16{NullInt16: sql.NullInt16{Int16: -32768, Valid: true}}
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := ns.UnmarshalJSON([]byte(`null`))
+			Convey("Then I should get a Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a sqljson.NullInt16 value pointer, and an out of range JSON value", t, func() {
+		ns := &sqljson.NullInt16{}
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := ns.UnmarshalJSON([]byte(`32768`))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "cannot unmarshal number 32768 into Go value of type int16")
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestInt16Scan(t *testing.T) {
+	Convey("Given a sqljson.NullInt16 value pointer", t, func() {
+		ns := &sqljson.NullInt16{}
+		Convey("When I scan an out of range value", func() {
+			err := ns.Scan(int64(32768))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "value out of range")
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestInt16Constructors(t *testing.T) {
+	Convey("Given a int16 value, a pointer to it, a nil pointer and the zero value", t, func() {
+		value := int16(1000)
+		Convey("When I build sqljson.NullInt16 values from them", func() {
+			fromValue := sqljson.NewNullInt16(value)
+			fromPtr := sqljson.NullInt16From(&value)
+			fromNil := sqljson.NullInt16From(nil)
+			fromZero := sqljson.NullInt16FromZero(int16(0))
+			fromNonZero := sqljson.NullInt16FromZero(value)
+			Convey("Then only the nil pointer and the zero value should be NULL", func() {
+				So(fromValue.Valid, ShouldBeTrue)
+				So(fromValue.Int16, ShouldEqual, value)
+				So(fromPtr.Valid, ShouldBeTrue)
+				So(fromPtr.Int16, ShouldEqual, value)
+				So(fromNil.Valid, ShouldBeFalse)
+				So(fromZero.Valid, ShouldBeFalse)
+				So(fromNonZero.Valid, ShouldBeTrue)
+			})
+			Convey("Then ValueOrZero and ValueOr should fall back only when NULL", func() {
+				So(fromValue.ValueOrZero(), ShouldEqual, value)
+				So(fromValue.ValueOr(int16(-1)), ShouldEqual, value)
+				So(fromNil.ValueOrZero(), ShouldEqual, int16(0))
+				So(fromNil.ValueOr(int16(-1)), ShouldEqual, int16(-1))
+			})
+		})
+	})
+}
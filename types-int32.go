@@ -0,0 +1,141 @@
+package sqljson
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/xml"
+	"math"
+	"reflect"
+)
+
+// NullInt32 //
+type NullInt32 struct {
+	sql.NullInt32
+}
+
+// NullInt32ValidateValuer //
+func NullInt32ValidateValuer(field reflect.Value) interface{} {
+	if nullInt32, ok := field.Interface().(NullInt32); ok {
+		return nullInt32.ValidateValue()
+	}
+	return nil
+}
+
+func (ns NullInt32) null() Null[int32] {
+	return newNull(ns.Int32, ns.Valid)
+}
+
+func nullInt32Of(n Null[int32]) NullInt32 {
+	return NullInt32{NullInt32: sql.NullInt32{Int32: n.V, Valid: n.Valid}}
+}
+
+// NewNullInt32 //
+func NewNullInt32(value int32) NullInt32 {
+	return nullInt32Of(NewNull(value))
+}
+
+// NullInt32From //
+func NullInt32From(value *int32) NullInt32 {
+	return nullInt32Of(NullFrom(value))
+}
+
+// NullInt32FromZero //
+func NullInt32FromZero(value int32) NullInt32 {
+	return nullInt32Of(NullFromZero(value))
+}
+
+// ValidateValue //
+func (ns NullInt32) ValidateValue() interface{} {
+	return ns.null().ValidateValue()
+}
+
+// Int32PtrOrNil //
+func (ns NullInt32) Int32PtrOrNil() *int32 {
+	return ns.null().PtrOrNil()
+}
+
+// ValueOrZero //
+func (ns NullInt32) ValueOrZero() int32 {
+	return ns.null().ValueOrZero()
+}
+
+// ValueOr //
+func (ns NullInt32) ValueOr(value int32) int32 {
+	return ns.null().ValueOr(value)
+}
+
+// IsZero //
+func (ns NullInt32) IsZero() bool {
+	return !ns.Valid
+}
+
+// Scan accepts the same representations as NullInt64.Scan, within the
+// range of an int32.
+func (ns *NullInt32) Scan(value interface{}) error {
+	if value == nil {
+		ns.Int32, ns.Valid = 0, false
+		return nil
+	}
+	v, err := scanInt(value, math.MinInt32, math.MaxInt32, "NullInt32")
+	if err != nil {
+		return err
+	}
+	ns.Int32, ns.Valid = int32(v), true
+	return nil
+}
+
+// Value //
+func (ns NullInt32) Value() (driver.Value, error) {
+	return ns.null().Value()
+}
+
+// MarshalJSON //
+func (ns NullInt32) MarshalJSON() ([]byte, error) {
+	return ns.null().MarshalJSON()
+}
+
+// UnmarshalJSON //
+func (ns *NullInt32) UnmarshalJSON(data []byte) error {
+	n := Null[int32]{}
+	err := n.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	ns.Int32 = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXML //
+func (ns NullInt32) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return ns.null().MarshalXML(e, start)
+}
+
+// UnmarshalXML //
+func (ns *NullInt32) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	n := Null[int32]{}
+	err := n.UnmarshalXML(d, start)
+	if err != nil {
+		return err
+	}
+	ns.Int32 = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXMLAttr //
+func (ns NullInt32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return ns.null().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullInt32) UnmarshalXMLAttr(attr xml.Attr) error {
+	n := Null[int32]{}
+	err := n.UnmarshalXMLAttr(attr)
+	if err != nil {
+		return err
+	}
+	ns.Int32 = n.V
+	ns.Valid = n.Valid
+	return nil
+}
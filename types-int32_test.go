@@ -0,0 +1,160 @@
+package sqljson_test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNullInt32ValidateValuer(t *testing.T) {
+	Convey("Given a non-null sqljson.NullInt32 value", t, func() {
+		ov := sqljson.NullInt32{
+			NullInt32: sql.NullInt32{
+				Int32: 2147483647,
+				Valid: true,
+			},
+		}
+		Convey("When evaluated", func() {
+			ivOut := sqljson.NullInt32ValidateValuer(reflect.ValueOf(ov))
+			Convey("Then we should get the value", func() {
+				nv, ok := ivOut.(int32)
+				So(ok, ShouldBeTrue)
+				So(nv, ShouldEqual, 2147483647)
+			})
+		})
+	})
+	Convey("Given a null sqljson.NullInt32 value", t, func() {
+		ov := sqljson.NullInt32{}
+		Convey("When evaluated", func() {
+			ivOut := sqljson.NullInt32ValidateValuer(reflect.ValueOf(ov))
+			Convey("Then we should get nil", func() {
+				So(ivOut, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestInt32PtrOrNil(t *testing.T) {
+	Convey("Given a valid non-Null NullInt32 value", t, func() {
+		value := sqljson.NullInt32{NullInt32: sql.NullInt32{Int32: 2147483647, Valid: true}}
+		Convey("When we get the Int32PtrOrNil", func() {
+			res := value.Int32PtrOrNil()
+			Convey("Then we get the value", func() {
+				So(res, ShouldNotBeNil)
+				So(*res, ShouldEqual, 2147483647)
+			})
+		})
+	})
+	Convey("Given a valid null NullInt32 value", t, func() {
+		value := sqljson.NullInt32{}
+		Convey("When we get the Int32PtrOrNil", func() {
+			res := value.Int32PtrOrNil()
+			Convey("Then we get nil", func() {
+				So(res, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestInt32MarshalJSON(t *testing.T) {
+	Convey("Given a non-null sqljson.NullInt32 value", t, func() {
+		valueIn := sqljson.NullInt32{NullInt32: sql.NullInt32{Int32: 2147483647, Valid: true}}
+		Convey("When I marshal it", func() {
+			b, err := valueIn.MarshalJSON()
+			Convey("Then I should get the number", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `2147483647`)
+			})
+		})
+	})
+	Convey("Given a null sqljson.NullInt32 value", t, func() {
+		valueIn := sqljson.NullInt32{}
+		Convey("When I marshal it", func() {
+			b, err := valueIn.MarshalJSON()
+			Convey("Then I should get null", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `null`)
+			})
+		})
+	})
+}
+
+func TestInt32UnmarshalJSON(t *testing.T) {
+	Convey("Given a sqljson.NullInt32 value pointer, and a number JSON value", t, func() {
+		ns := &sqljson.NullInt32{}
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := ns.UnmarshalJSON([]byte(`2147483647`))
+			Convey("Then I should get a not-Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeTrue)
+				So(ns.Int32, ShouldEqual, 2147483647)
+			})
+		})
+	})
+	Convey("Given a sqljson.NullInt32 value pointer, and a null JSON value", t, func() {
+		ns := &sqljson.NullInt32{NullInt32: sql.NullInt32{Int32: 2147483647, Valid: true}}
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := ns.UnmarshalJSON([]byte(`null`))
+			Convey("Then I should get a Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a sqljson.NullInt32 value pointer, and an out of range JSON value", t, func() {
+		ns := &sqljson.NullInt32{}
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := ns.UnmarshalJSON([]byte(`2147483648`))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "cannot unmarshal number 2147483648 into Go value of type int32")
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestInt32Scan(t *testing.T) {
+	Convey("Given a sqljson.NullInt32 value pointer", t, func() {
+		ns := &sqljson.NullInt32{}
+		Convey("When I scan an out of range value", func() {
+			err := ns.Scan(int64(2147483648))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "value out of range")
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestInt32Constructors(t *testing.T) {
+	Convey("Given a int32 value, a pointer to it, a nil pointer and the zero value", t, func() {
+		value := int32(1000)
+		Convey("When I build sqljson.NullInt32 values from them", func() {
+			fromValue := sqljson.NewNullInt32(value)
+			fromPtr := sqljson.NullInt32From(&value)
+			fromNil := sqljson.NullInt32From(nil)
+			fromZero := sqljson.NullInt32FromZero(int32(0))
+			fromNonZero := sqljson.NullInt32FromZero(value)
+			Convey("Then only the nil pointer and the zero value should be NULL", func() {
+				So(fromValue.Valid, ShouldBeTrue)
+				So(fromValue.Int32, ShouldEqual, value)
+				So(fromPtr.Valid, ShouldBeTrue)
+				So(fromPtr.Int32, ShouldEqual, value)
+				So(fromNil.Valid, ShouldBeFalse)
+				So(fromZero.Valid, ShouldBeFalse)
+				So(fromNonZero.Valid, ShouldBeTrue)
+			})
+			Convey("Then ValueOrZero and ValueOr should fall back only when NULL", func() {
+				So(fromValue.ValueOrZero(), ShouldEqual, value)
+				So(fromValue.ValueOr(int32(-1)), ShouldEqual, value)
+				So(fromNil.ValueOrZero(), ShouldEqual, int32(0))
+				So(fromNil.ValueOr(int32(-1)), ShouldEqual, int32(-1))
+			})
+		})
+	})
+}
@@ -2,8 +2,12 @@ package sqljson
 
 import (
 	"database/sql"
-	"encoding/json"
+	"database/sql/driver"
+	"encoding/xml"
+	"fmt"
+	"math"
 	"reflect"
+	"strconv"
 )
 
 // NullInt64 //
@@ -14,43 +18,160 @@ type NullInt64 struct {
 // NullInt64ValidateValuer //
 func NullInt64ValidateValuer(field reflect.Value) interface{} {
 	if nullInt64, ok := field.Interface().(NullInt64); ok {
-		if nullInt64.Valid {
-			return nullInt64.Int64
-		}
+		return nullInt64.ValidateValue()
 	}
 	return nil
 }
 
+func (ns NullInt64) null() Null[int64] {
+	return newNull(ns.Int64, ns.Valid)
+}
+
+func nullInt64Of(n Null[int64]) NullInt64 {
+	return NullInt64{NullInt64: sql.NullInt64{Int64: n.V, Valid: n.Valid}}
+}
+
+// NewNullInt64 //
+func NewNullInt64(value int64) NullInt64 {
+	return nullInt64Of(NewNull(value))
+}
+
+// NullInt64From //
+func NullInt64From(value *int64) NullInt64 {
+	return nullInt64Of(NullFrom(value))
+}
+
+// NullInt64FromZero //
+func NullInt64FromZero(value int64) NullInt64 {
+	return nullInt64Of(NullFromZero(value))
+}
+
+// ValidateValue //
+func (ns NullInt64) ValidateValue() interface{} {
+	return ns.null().ValidateValue()
+}
+
 // Int64PtrOrNil //
 func (ns NullInt64) Int64PtrOrNil() *int64 {
-	if ns.Valid {
-		s := ns.Int64
-		return &s
+	return ns.null().PtrOrNil()
+}
+
+// ValueOrZero //
+func (ns NullInt64) ValueOrZero() int64 {
+	return ns.null().ValueOrZero()
+}
+
+// ValueOr //
+func (ns NullInt64) ValueOr(value int64) int64 {
+	return ns.null().ValueOr(value)
+}
+
+// IsZero //
+func (ns NullInt64) IsZero() bool {
+	return !ns.Valid
+}
+
+// Scan accepts integers, integral floats, booleans and decimal texts such
+// as "12" or "12.00".
+func (ns *NullInt64) Scan(value interface{}) error {
+	if value == nil {
+		ns.Int64, ns.Valid = 0, false
+		return nil
+	}
+	v, err := scanInt(value, math.MinInt64, math.MaxInt64, "NullInt64")
+	if err != nil {
+		return err
 	}
+	ns.Int64, ns.Valid = v, true
 	return nil
 }
 
+// Value //
+func (ns NullInt64) Value() (driver.Value, error) {
+	return ns.null().Value()
+}
+
 // MarshalJSON //
 func (ns NullInt64) MarshalJSON() ([]byte, error) {
-	if ns.Valid {
-		return json.Marshal(ns.Int64)
-	}
-	return json.Marshal(nil)
+	return ns.null().MarshalJSON()
 }
 
 // UnmarshalJSON //
 func (ns *NullInt64) UnmarshalJSON(data []byte) error {
-	value := new(int64)
-	err := json.Unmarshal(data, &value)
+	n := Null[int64]{}
+	err := n.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	ns.Int64 = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXML //
+func (ns NullInt64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return ns.null().MarshalXML(e, start)
+}
+
+// UnmarshalXML //
+func (ns *NullInt64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	n := Null[int64]{}
+	err := n.UnmarshalXML(d, start)
+	if err != nil {
+		return err
+	}
+	ns.Int64 = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXMLAttr //
+func (ns NullInt64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return ns.null().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullInt64) UnmarshalXMLAttr(attr xml.Attr) error {
+	n := Null[int64]{}
+	err := n.UnmarshalXMLAttr(attr)
 	if err != nil {
 		return err
 	}
-	if value != nil {
-		ns.Int64 = *value
-		ns.Valid = true
-	} else {
+	ns.Int64 = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// text returns the text of a non-NULL value.
+func (ns NullInt64) text() string {
+	return strconv.FormatInt(ns.Int64, 10)
+}
+
+// setText sets ns to the non-NULL value given as text.
+func (ns *NullInt64) setText(text string) error {
+	value, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return fmt.Errorf("sqljson: cannot unmarshal text %q into an int64", text)
+	}
+	ns.Int64 = value
+	ns.Valid = true
+	return nil
+}
+
+// MarshalText //
+func (ns NullInt64) MarshalText() ([]byte, error) {
+	if !ns.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(ns.text()), nil
+}
+
+// UnmarshalText //
+func (ns *NullInt64) UnmarshalText(data []byte) error {
+	if isNullText(data) {
 		ns.Int64 = 0
 		ns.Valid = false
+		return nil
 	}
-	return nil
+	return ns.setText(string(data))
 }
@@ -205,3 +205,78 @@ func TestInt64UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestInt64Constructors(t *testing.T) {
+	Convey("Given a int64 value, a pointer to it, a nil pointer and the zero value", t, func() {
+		value := int64(1000)
+		Convey("When I build sqljson.NullInt64 values from them", func() {
+			fromValue := sqljson.NewNullInt64(value)
+			fromPtr := sqljson.NullInt64From(&value)
+			fromNil := sqljson.NullInt64From(nil)
+			fromZero := sqljson.NullInt64FromZero(int64(0))
+			fromNonZero := sqljson.NullInt64FromZero(value)
+			Convey("Then only the nil pointer and the zero value should be NULL", func() {
+				So(fromValue.Valid, ShouldBeTrue)
+				So(fromValue.Int64, ShouldEqual, value)
+				So(fromPtr.Valid, ShouldBeTrue)
+				So(fromPtr.Int64, ShouldEqual, value)
+				So(fromNil.Valid, ShouldBeFalse)
+				So(fromZero.Valid, ShouldBeFalse)
+				So(fromNonZero.Valid, ShouldBeTrue)
+			})
+			Convey("Then ValueOrZero and ValueOr should fall back only when NULL", func() {
+				So(fromValue.ValueOrZero(), ShouldEqual, value)
+				So(fromValue.ValueOr(int64(-1)), ShouldEqual, value)
+				So(fromNil.ValueOrZero(), ShouldEqual, int64(0))
+				So(fromNil.ValueOr(int64(-1)), ShouldEqual, int64(-1))
+			})
+		})
+	})
+}
+
+func TestInt64MarshalText(t *testing.T) {
+	Convey("Given a non-null and a null sqljson.NullInt64 value", t, func() {
+		valueIn := sqljson.NewNullInt64(-42)
+		null := sqljson.NullInt64{}
+		Convey("When I marshal them as text", func() {
+			b, err := valueIn.MarshalText()
+			bNull, errNull := null.MarshalText()
+			Convey("Then I should get the value and sqljson.NullText", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "-42")
+				So(errNull, ShouldBeNil)
+				So(string(bNull), ShouldEqual, sqljson.NullText)
+			})
+		})
+	})
+}
+
+func TestInt64UnmarshalText(t *testing.T) {
+	Convey("Given a sqljson.NullInt64 value pointer, and a text value", t, func() {
+		ns := &sqljson.NullInt64{}
+		Convey("When I Unmarshal it using UnmarshalText", func() {
+			err := ns.UnmarshalText([]byte("-42"))
+			Convey("Then I should get a not-Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeTrue)
+				So(ns.Int64, ShouldEqual, -42)
+			})
+		})
+		Convey("When I Unmarshal sqljson.NullText using UnmarshalText", func() {
+			_ = ns.UnmarshalText([]byte("-42"))
+			err := ns.UnmarshalText([]byte(sqljson.NullText))
+			Convey("Then I should get a Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+		Convey("When I Unmarshal an invalid text using UnmarshalText", func() {
+			err := ns.UnmarshalText([]byte("4.2"))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "cannot unmarshal text")
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
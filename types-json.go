@@ -0,0 +1,271 @@
+package sqljson
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+)
+
+// NullRawMessage is a nullable JSON document, as stored in JSON or jsonb
+// columns. It is embedded as-is when marshaled to JSON.
+type NullRawMessage struct {
+	RawMessage json.RawMessage
+	Valid      bool
+}
+
+// NullJSON is a nullable JSON document, as stored in JSON or jsonb columns,
+// decoded into a value of type T.
+type NullJSON[T any] struct {
+	Null[T]
+}
+
+// NewNullRawMessage //
+func NewNullRawMessage(value json.RawMessage) NullRawMessage {
+	return NullRawMessage{RawMessage: value, Valid: true}
+}
+
+// NullRawMessageFrom //
+func NullRawMessageFrom(value *json.RawMessage) NullRawMessage {
+	if value == nil {
+		return NullRawMessage{}
+	}
+	return NewNullRawMessage(*value)
+}
+
+// NullRawMessageFromZero returns NULL for an empty document and for a JSON
+// null document.
+func NullRawMessageFromZero(value json.RawMessage) NullRawMessage {
+	trimmed := bytes.TrimSpace(value)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return NullRawMessage{}
+	}
+	return NewNullRawMessage(value)
+}
+
+// NewNullJSON //
+func NewNullJSON[T any](value T) NullJSON[T] {
+	return NullJSON[T]{Null: NewNull(value)}
+}
+
+// NullJSONFrom //
+func NullJSONFrom[T any](value *T) NullJSON[T] {
+	return NullJSON[T]{Null: NullFrom(value)}
+}
+
+// NullJSONFromZero //
+func NullJSONFromZero[T any](value T) NullJSON[T] {
+	return NullJSON[T]{Null: NullFromZero(value)}
+}
+
+// jsonBytes returns the JSON document in a driver value. ok is false for a
+// NULL value or a JSON null document.
+func jsonBytes(value interface{}) (data []byte, ok bool, err error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, false, nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return nil, false, fmt.Errorf("sqljson: cannot scan %T into a JSON document", value)
+	}
+	if !json.Valid(data) {
+		return nil, false, fmt.Errorf("sqljson: cannot scan invalid JSON document %q", data)
+	}
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil, false, nil
+	}
+	return data, true, nil
+}
+
+// NullRawMessageValidateValuer //
+func NullRawMessageValidateValuer(field reflect.Value) interface{} {
+	if nullRawMessage, ok := field.Interface().(NullRawMessage); ok {
+		return nullRawMessage.ValidateValue()
+	}
+	return nil
+}
+
+// ValidateValue //
+func (ns NullRawMessage) ValidateValue() interface{} {
+	if ns.Valid {
+		return string(ns.RawMessage)
+	}
+	return nil
+}
+
+// RawMessagePtrOrNil //
+func (ns NullRawMessage) RawMessagePtrOrNil() *json.RawMessage {
+	if ns.Valid {
+		s := append(json.RawMessage{}, ns.RawMessage...)
+		return &s
+	}
+	return nil
+}
+
+// ValueOrZero //
+func (ns NullRawMessage) ValueOrZero() json.RawMessage {
+	return ns.ValueOr(nil)
+}
+
+// ValueOr //
+func (ns NullRawMessage) ValueOr(value json.RawMessage) json.RawMessage {
+	if ns.Valid {
+		return ns.RawMessage
+	}
+	return value
+}
+
+// IsZero //
+func (ns NullRawMessage) IsZero() bool {
+	return !ns.Valid
+}
+
+// Scan //
+func (ns *NullRawMessage) Scan(value interface{}) error {
+	data, ok, err := jsonBytes(value)
+	if err != nil {
+		return err
+	}
+	ns.RawMessage = append(json.RawMessage(nil), data...)
+	ns.Valid = ok
+	return nil
+}
+
+// Value //
+func (ns NullRawMessage) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RawMessage), nil
+}
+
+// MarshalJSON //
+func (ns NullRawMessage) MarshalJSON() ([]byte, error) {
+	if ns.Valid && len(ns.RawMessage) > 0 {
+		return ns.RawMessage, nil
+	}
+	return json.Marshal(nil)
+}
+
+// UnmarshalJSON //
+func (ns *NullRawMessage) UnmarshalJSON(data []byte) error {
+	if !json.Valid(data) {
+		return fmt.Errorf("sqljson: cannot unmarshal invalid JSON document %q", data)
+	}
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		ns.RawMessage = nil
+		ns.Valid = false
+		return nil
+	}
+	ns.RawMessage = append(json.RawMessage(nil), data...)
+	ns.Valid = true
+	return nil
+}
+
+// MarshalXML marshals the document as the element text.
+func (ns NullRawMessage) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(e, start, string(ns.RawMessage), ns.Valid)
+}
+
+// UnmarshalXML //
+func (ns *NullRawMessage) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	text, ok, err := unmarshalXMLText(d, start)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		ns.RawMessage = nil
+		ns.Valid = false
+		return nil
+	}
+	return ns.UnmarshalJSON([]byte(text))
+}
+
+// MarshalXMLAttr //
+func (ns NullRawMessage) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, string(ns.RawMessage), ns.Valid)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullRawMessage) UnmarshalXMLAttr(attr xml.Attr) error {
+	return ns.UnmarshalJSON([]byte(attr.Value))
+}
+
+// Scan //
+func (ns *NullJSON[T]) Scan(value interface{}) error {
+	data, ok, err := jsonBytes(value)
+	if err != nil {
+		return err
+	}
+	var v T
+	if ok {
+		err = json.Unmarshal(data, &v)
+		if err != nil {
+			return err
+		}
+	}
+	ns.V = v
+	ns.Valid = ok
+	return nil
+}
+
+// Value //
+func (ns NullJSON[T]) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	data, err := json.Marshal(ns.V)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// MarshalXML marshals the document as the element text, encoded as JSON.
+func (ns NullJSON[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !ns.Valid {
+		return marshalXMLNull(e, start)
+	}
+	data, err := json.Marshal(ns.V)
+	if err != nil {
+		return err
+	}
+	return marshalXMLText(e, start, string(data), true)
+}
+
+// UnmarshalXML //
+func (ns *NullJSON[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	text, ok, err := unmarshalXMLText(d, start)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		var zero T
+		ns.V = zero
+		ns.Valid = false
+		return nil
+	}
+	return ns.UnmarshalJSON([]byte(text))
+}
+
+// MarshalXMLAttr //
+func (ns NullJSON[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !ns.Valid {
+		return xml.Attr{}, nil
+	}
+	data, err := json.Marshal(ns.V)
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return marshalXMLAttrText(name, string(data), true)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullJSON[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return ns.UnmarshalJSON([]byte(attr.Value))
+}
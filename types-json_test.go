@@ -0,0 +1,226 @@
+package sqljson_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	validator "gopkg.in/go-playground/validator.v9"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNullRawMessageValidateValuer(t *testing.T) {
+	Convey("Given a non-null sqljson.NullRawMessage value", t, func() {
+		ov := sqljson.NullRawMessage{RawMessage: json.RawMessage(`{"a":1}`), Valid: true}
+		Convey("When evaluated", func() {
+			ivOut := sqljson.NullRawMessageValidateValuer(reflect.ValueOf(ov))
+			Convey("Then we should get the document as a string", func() {
+				So(ivOut, ShouldEqual, `{"a":1}`)
+			})
+		})
+	})
+	Convey("Given a null sqljson.NullRawMessage value", t, func() {
+		ov := sqljson.NullRawMessage{}
+		Convey("When evaluated", func() {
+			ivOut := sqljson.NullRawMessageValidateValuer(reflect.ValueOf(ov))
+			Convey("Then we should get nil", func() {
+				So(ivOut, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestRawMessageJSON(t *testing.T) {
+	type document struct {
+		ID       int                    `json:"id"`
+		Settings sqljson.NullRawMessage `json:"settings"`
+		Extra    sqljson.NullRawMessage `json:"extra"`
+	}
+	Convey("Given a JSON string with an embedded document and a null one", t, func() {
+		strJSON := []byte(`{"id": 1, "settings": {"theme": "dark", "tags": [1, 2]}, "extra": null}`)
+		Convey("When I unmarshal and marshal it again", func() {
+			doc := &document{}
+			err := json.Unmarshal(strJSON, doc)
+			b, errMarshal := json.Marshal(doc)
+			Convey("Then the document should be embedded inline", func() {
+				So(err, ShouldBeNil)
+				So(doc.Settings.Valid, ShouldBeTrue)
+				So(doc.Extra.Valid, ShouldBeFalse)
+				So(errMarshal, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"id":1,"settings":{"theme":"dark","tags":[1,2]},"extra":null}`)
+			})
+		})
+	})
+	Convey("Given a sqljson.NullRawMessage value pointer, and an invalid JSON value", t, func() {
+		ns := &sqljson.NullRawMessage{}
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := ns.UnmarshalJSON([]byte(`{"a":`))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "invalid JSON document")
+			})
+		})
+	})
+}
+
+func TestRawMessageSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	Convey("Given a sql mock returning JSON documents as bytes, as a string and NULL", t, func() {
+		mock.
+			ExpectQuery(`SELECT a, b, c FROM documents`).
+			WillReturnRows(sqlmock.NewRows([]string{"a", "b", "c"}).AddRow([]byte(`{"x": 1}`), `[true]`, nil))
+		Convey("When I query a row and scan it", func() {
+			a, b, c := sqljson.NullRawMessage{}, sqljson.NullRawMessage{}, sqljson.NullRawMessage{}
+			dbErr := db.QueryRow(`SELECT a, b, c FROM documents`).Scan(&a, &b, &c)
+			Convey("Then I should get the documents", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+				So(a.Valid, ShouldBeTrue)
+				So(string(a.RawMessage), ShouldEqual, `{"x": 1}`)
+				So(b.Valid, ShouldBeTrue)
+				So(string(b.RawMessage), ShouldEqual, `[true]`)
+				So(c.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a sql mock returning an invalid JSON document", t, func() {
+		mock.
+			ExpectQuery(`SELECT a FROM documents`).
+			WillReturnRows(sqlmock.NewRows([]string{"a"}).AddRow(`{oops}`))
+		Convey("When I query a row and scan it", func() {
+			a := sqljson.NullRawMessage{}
+			dbErr := db.QueryRow(`SELECT a FROM documents`).Scan(&a)
+			Convey("Then I should get an error", func() {
+				So(dbErr, ShouldNotBeNil)
+				So(dbErr.Error(), ShouldContainSubstring, "invalid JSON document")
+			})
+		})
+	})
+	Convey("Given a valid and a null sqljson.NullRawMessage", t, func() {
+		a := sqljson.NullRawMessage{RawMessage: json.RawMessage(`{"x":1}`), Valid: true}
+		b := sqljson.NullRawMessage{}
+		Convey("When I write them to the database", func() {
+			mock.
+				ExpectExec(`UPDATE documents SET a = \?, b = \?`).
+				WithArgs(`{"x":1}`, nil).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			_, dbErr := db.Exec(`UPDATE documents SET a = ?, b = ?`, a, b)
+			Convey("Then they should be written as text and NULL", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestNullJSON(t *testing.T) {
+	type settings struct {
+		Theme string `json:"theme" validate:"required"`
+		Size  int    `json:"size"`
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	Convey("Given a sql mock returning a JSON document and NULL", t, func() {
+		mock.
+			ExpectQuery(`SELECT a, b FROM documents`).
+			WillReturnRows(sqlmock.NewRows([]string{"a", "b"}).AddRow([]byte(`{"theme": "dark", "size": 3}`), nil))
+		Convey("When I query a row and scan it into sqljson.NullJSON", func() {
+			a, b := sqljson.NullJSON[settings]{}, sqljson.NullJSON[settings]{}
+			dbErr := db.QueryRow(`SELECT a, b FROM documents`).Scan(&a, &b)
+			Convey("Then I should get the decoded document and a Null value", func() {
+				So(dbErr, ShouldBeNil)
+				So(a.Valid, ShouldBeTrue)
+				So(a.V, ShouldResemble, settings{Theme: "dark", Size: 3})
+				So(b.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a sql mock returning a JSON document of the wrong shape", t, func() {
+		mock.
+			ExpectQuery(`SELECT a FROM documents`).
+			WillReturnRows(sqlmock.NewRows([]string{"a"}).AddRow(`[1, 2]`))
+		Convey("When I query a row and scan it into sqljson.NullJSON", func() {
+			a := sqljson.NullJSON[settings]{}
+			dbErr := db.QueryRow(`SELECT a FROM documents`).Scan(&a)
+			Convey("Then I should get an error", func() {
+				So(dbErr, ShouldNotBeNil)
+				So(dbErr.Error(), ShouldContainSubstring, "cannot unmarshal array")
+			})
+		})
+	})
+	Convey("Given a sqljson.NullJSON value", t, func() {
+		a := sqljson.NullJSON[settings]{}
+		So(a.UnmarshalJSON([]byte(`{"theme": "light"}`)), ShouldBeNil)
+		Convey("When I write it to the database and marshal it", func() {
+			mock.
+				ExpectExec(`UPDATE documents SET a = \?`).
+				WithArgs(`{"theme":"light","size":0}`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			_, dbErr := db.Exec(`UPDATE documents SET a = ?`, a)
+			b, errMarshal := json.Marshal(a)
+			Convey("Then it should be encoded as JSON in both cases", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+				So(errMarshal, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"theme":"light","size":0}`)
+			})
+		})
+	})
+	Convey("Given a validator and a struct holding a sqljson.NullJSON", t, func() {
+		validate := validator.New()
+		So(sqljson.RegisterValidator(validate, sqljson.NullJSON[settings]{}), ShouldBeNil)
+		s := &struct {
+			Settings sqljson.NullJSON[settings] `validate:"required"`
+		}{}
+		Convey("When I validate it while NULL", func() {
+			err := validate.Struct(s)
+			Convey("Then it should be reported as missing", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(validator.ValidationErrors)[0].Tag(), ShouldEqual, "required")
+			})
+		})
+	})
+}
+
+func TestRawMessageConstructors(t *testing.T) {
+	Convey("Given JSON documents, a pointer to one and a nil pointer", t, func() {
+		value := json.RawMessage(`{"a":1}`)
+		Convey("When I build sqljson.NullRawMessage and NullJSON values from them", func() {
+			fromValue := sqljson.NewNullRawMessage(value)
+			fromPtr := sqljson.NullRawMessageFrom(&value)
+			fromNil := sqljson.NullRawMessageFrom(nil)
+			fromEmpty := sqljson.NullRawMessageFromZero(nil)
+			fromNull := sqljson.NullRawMessageFromZero(json.RawMessage(` null `))
+			typed := sqljson.NewNullJSON(map[string]int{"a": 1})
+			typedZero := sqljson.NullJSONFromZero(map[string]int(nil))
+			Convey("Then only the nil pointer and the empty or null documents should be NULL", func() {
+				So(fromValue.Valid, ShouldBeTrue)
+				So(fromPtr.Valid, ShouldBeTrue)
+				So(fromNil.Valid, ShouldBeFalse)
+				So(fromEmpty.Valid, ShouldBeFalse)
+				So(fromNull.Valid, ShouldBeFalse)
+				So(typed.Valid, ShouldBeTrue)
+				So(typedZero.Valid, ShouldBeFalse)
+			})
+			Convey("Then ValueOrZero and ValueOr should fall back only when NULL", func() {
+				So(string(fromValue.ValueOrZero()), ShouldEqual, `{"a":1}`)
+				So(fromNil.ValueOrZero(), ShouldBeNil)
+				So(string(fromNil.ValueOr(json.RawMessage(`[]`))), ShouldEqual, `[]`)
+				So(typed.ValueOrZero()["a"], ShouldEqual, 1)
+			})
+		})
+	})
+}
@@ -0,0 +1,155 @@
+package sqljson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// LenientNullBool is a NullBool that also accepts booleans sent as JSON
+// strings, such as "true" or "0", and decodes an empty string as null.
+type LenientNullBool struct {
+	NullBool
+}
+
+// LenientNullInt64 is a NullInt64 that also accepts integers sent as JSON
+// strings, such as "123", and decodes an empty string as null.
+type LenientNullInt64 struct {
+	NullInt64
+}
+
+// LenientNullFloat64 is a NullFloat64 that also accepts numbers sent as JSON
+// strings, such as "12.5", and decodes an empty string as null.
+type LenientNullFloat64 struct {
+	NullFloat64
+}
+
+// NewLenientNullBool //
+func NewLenientNullBool(value bool) LenientNullBool {
+	return LenientNullBool{NullBool: NewNullBool(value)}
+}
+
+// LenientNullBoolFrom //
+func LenientNullBoolFrom(value *bool) LenientNullBool {
+	return LenientNullBool{NullBool: NullBoolFrom(value)}
+}
+
+// LenientNullBoolFromZero //
+func LenientNullBoolFromZero(value bool) LenientNullBool {
+	return LenientNullBool{NullBool: NullBoolFromZero(value)}
+}
+
+// NewLenientNullInt64 //
+func NewLenientNullInt64(value int64) LenientNullInt64 {
+	return LenientNullInt64{NullInt64: NewNullInt64(value)}
+}
+
+// LenientNullInt64From //
+func LenientNullInt64From(value *int64) LenientNullInt64 {
+	return LenientNullInt64{NullInt64: NullInt64From(value)}
+}
+
+// LenientNullInt64FromZero //
+func LenientNullInt64FromZero(value int64) LenientNullInt64 {
+	return LenientNullInt64{NullInt64: NullInt64FromZero(value)}
+}
+
+// NewLenientNullFloat64 //
+func NewLenientNullFloat64(value float64) LenientNullFloat64 {
+	return LenientNullFloat64{NullFloat64: NewNullFloat64(value)}
+}
+
+// LenientNullFloat64From //
+func LenientNullFloat64From(value *float64) LenientNullFloat64 {
+	return LenientNullFloat64{NullFloat64: NullFloat64From(value)}
+}
+
+// LenientNullFloat64FromZero //
+func LenientNullFloat64FromZero(value float64) LenientNullFloat64 {
+	return LenientNullFloat64{NullFloat64: NullFloat64FromZero(value)}
+}
+
+// lenientText returns the trimmed contents of data when it is a JSON string.
+// ok is false for any other JSON value, which is then decoded strictly.
+func lenientText(data []byte) (text string, ok bool, err error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		return "", false, nil
+	}
+	err = json.Unmarshal(data, &text)
+	if err != nil {
+		return "", false, err
+	}
+	return strings.TrimSpace(text), true, nil
+}
+
+// UnmarshalJSON //
+func (ns *LenientNullBool) UnmarshalJSON(data []byte) error {
+	text, ok, err := lenientText(data)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ns.NullBool.UnmarshalJSON(data)
+	}
+	if text == "" {
+		ns.Bool = false
+		ns.Valid = false
+		return nil
+	}
+	value, err := strconv.ParseBool(text)
+	if err != nil {
+		return fmt.Errorf("sqljson: cannot unmarshal string %q into a bool", text)
+	}
+	ns.Bool = value
+	ns.Valid = true
+	return nil
+}
+
+// UnmarshalJSON //
+func (ns *LenientNullInt64) UnmarshalJSON(data []byte) error {
+	text, ok, err := lenientText(data)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ns.NullInt64.UnmarshalJSON(data)
+	}
+	if text == "" {
+		ns.Int64 = 0
+		ns.Valid = false
+		return nil
+	}
+	value, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return fmt.Errorf("sqljson: cannot unmarshal string %q into an int64", text)
+	}
+	ns.Int64 = value
+	ns.Valid = true
+	return nil
+}
+
+// UnmarshalJSON //
+func (ns *LenientNullFloat64) UnmarshalJSON(data []byte) error {
+	text, ok, err := lenientText(data)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ns.NullFloat64.UnmarshalJSON(data)
+	}
+	if text == "" {
+		ns.Float64 = 0.0
+		ns.Valid = false
+		return nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("sqljson: cannot unmarshal string %q into a float64", text)
+	}
+	ns.Float64 = value
+	ns.Valid = true
+	return nil
+}
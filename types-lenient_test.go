@@ -0,0 +1,167 @@
+package sqljson_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLenientNullBoolUnmarshalJSON(t *testing.T) {
+	Convey("Given a sqljson.LenientNullBool value pointer", t, func() {
+		ns := &sqljson.LenientNullBool{}
+		Convey("When I Unmarshal a native boolean", func() {
+			err := ns.UnmarshalJSON([]byte(`true`))
+			Convey("Then I should get the boolean", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeTrue)
+				So(ns.Bool, ShouldBeTrue)
+			})
+		})
+		Convey("When I Unmarshal quoted booleans", func() {
+			errTrue := ns.UnmarshalJSON([]byte(`"true"`))
+			validTrue, boolTrue := ns.Valid, ns.Bool
+			errZero := ns.UnmarshalJSON([]byte(`" 0 "`))
+			Convey("Then I should get the coerced booleans", func() {
+				So(errTrue, ShouldBeNil)
+				So(validTrue, ShouldBeTrue)
+				So(boolTrue, ShouldBeTrue)
+				So(errZero, ShouldBeNil)
+				So(ns.Valid, ShouldBeTrue)
+				So(ns.Bool, ShouldBeFalse)
+			})
+		})
+		Convey("When I Unmarshal an empty string", func() {
+			err := ns.UnmarshalJSON([]byte(`""`))
+			Convey("Then I should get a Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+		Convey("When I Unmarshal a non-boolean string", func() {
+			err := ns.UnmarshalJSON([]byte(`"yes please"`))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, `cannot unmarshal string "yes please" into a bool`)
+			})
+		})
+		Convey("When I Unmarshal a number", func() {
+			err := ns.UnmarshalJSON([]byte(`1`))
+			Convey("Then I should get the strict mode error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "cannot unmarshal number")
+			})
+		})
+	})
+}
+
+func TestLenientNullInt64UnmarshalJSON(t *testing.T) {
+	Convey("Given a sqljson.LenientNullInt64 value pointer", t, func() {
+		ns := &sqljson.LenientNullInt64{}
+		Convey("When I Unmarshal a quoted integer", func() {
+			err := ns.UnmarshalJSON([]byte(`"123"`))
+			Convey("Then I should get the integer", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeTrue)
+				So(ns.Int64, ShouldEqual, 123)
+			})
+		})
+		Convey("When I Unmarshal a native integer and then null", func() {
+			errNumber := ns.UnmarshalJSON([]byte(`-5`))
+			value := ns.Int64
+			errNull := ns.UnmarshalJSON([]byte(`null`))
+			Convey("Then I should get the integer and then a Null value", func() {
+				So(errNumber, ShouldBeNil)
+				So(value, ShouldEqual, -5)
+				So(errNull, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+		Convey("When I Unmarshal a blank string", func() {
+			err := ns.UnmarshalJSON([]byte(`"  "`))
+			Convey("Then I should get a Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+		Convey("When I Unmarshal a quoted decimal", func() {
+			err := ns.UnmarshalJSON([]byte(`"12.5"`))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, `cannot unmarshal string "12.5" into an int64`)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestLenientNullFloat64UnmarshalJSON(t *testing.T) {
+	Convey("Given a sqljson.LenientNullFloat64 value pointer", t, func() {
+		ns := &sqljson.LenientNullFloat64{}
+		Convey("When I Unmarshal a quoted decimal", func() {
+			err := ns.UnmarshalJSON([]byte(`"12.5"`))
+			Convey("Then I should get the number", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeTrue)
+				So(ns.Float64, ShouldEqual, 12.5)
+			})
+		})
+		Convey("When I Unmarshal a quoted NaN", func() {
+			err := ns.UnmarshalJSON([]byte(`"NaN"`))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, `cannot unmarshal string "NaN" into a float64`)
+			})
+		})
+	})
+}
+
+func TestLenientJSONIntegration(t *testing.T) {
+	type lenientStruct struct {
+		IsAdmin     sqljson.LenientNullBool    `json:"is_admin"`
+		Followers   sqljson.LenientNullInt64   `json:"followers"`
+		BankBalance sqljson.LenientNullFloat64 `json:"bank_balance"`
+	}
+	Convey("Given a JSON string using stringified values", t, func() {
+		strJSON := []byte(`{"is_admin": "false", "followers": "1000", "bank_balance": ""}`)
+		Convey("When I unmarshal and marshal it again", func() {
+			s := &lenientStruct{}
+			err := json.Unmarshal(strJSON, s)
+			b, errMarshal := json.Marshal(s)
+			Convey("Then I should get native JSON values back", func() {
+				So(err, ShouldBeNil)
+				So(errMarshal, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"is_admin":false,"followers":1000,"bank_balance":null}`)
+			})
+		})
+	})
+}
+
+func TestLenientConstructors(t *testing.T) {
+	Convey("Given values, pointers to them and nil pointers", t, func() {
+		b, i, f := true, int64(5), 12.5
+		Convey("When I build sqljson.Lenient* values from them", func() {
+			fromBool, fromBoolPtr, fromBoolNil, fromBoolZero := sqljson.NewLenientNullBool(b), sqljson.LenientNullBoolFrom(&b),
+				sqljson.LenientNullBoolFrom(nil), sqljson.LenientNullBoolFromZero(false)
+			fromInt, fromIntPtr, fromIntNil, fromIntZero := sqljson.NewLenientNullInt64(i), sqljson.LenientNullInt64From(&i),
+				sqljson.LenientNullInt64From(nil), sqljson.LenientNullInt64FromZero(0)
+			fromFloat, fromFloatPtr, fromFloatNil, fromFloatZero := sqljson.NewLenientNullFloat64(f), sqljson.LenientNullFloat64From(&f),
+				sqljson.LenientNullFloat64From(nil), sqljson.LenientNullFloat64FromZero(0)
+			Convey("Then only the nil pointers and the zero values should be NULL", func() {
+				So(fromBool.Valid && fromBool.Bool, ShouldBeTrue)
+				So(fromBoolPtr.Valid, ShouldBeTrue)
+				So(fromBoolNil.Valid, ShouldBeFalse)
+				So(fromBoolZero.Valid, ShouldBeFalse)
+				So(fromInt.Int64, ShouldEqual, 5)
+				So(fromIntPtr.Valid, ShouldBeTrue)
+				So(fromIntNil.Valid, ShouldBeFalse)
+				So(fromIntZero.Valid, ShouldBeFalse)
+				So(fromFloat.Float64, ShouldEqual, 12.5)
+				So(fromFloatPtr.Valid, ShouldBeTrue)
+				So(fromFloatNil.Valid, ShouldBeFalse)
+				So(fromFloatZero.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -0,0 +1,221 @@
+package sqljson
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Null is a nullable value of any type T that database/sql can scan,
+// with the same JSON and validator support as the other sqljson types.
+// Scan and Value are provided by the embedded sql.Null.
+type Null[T any] struct {
+	sql.Null[T]
+}
+
+// validateValuer is implemented by every sqljson type, returning the
+// wrapped value, or nil when it is NULL.
+type validateValuer interface {
+	ValidateValue() interface{}
+}
+
+// ValidateValuer is a validator custom type func that works with every
+// sqljson type, including any instantiation of Null.
+func ValidateValuer(field reflect.Value) interface{} {
+	if value, ok := field.Interface().(validateValuer); ok {
+		return value.ValidateValue()
+	}
+	return nil
+}
+
+func newNull[T any](value T, valid bool) Null[T] {
+	return Null[T]{Null: sql.Null[T]{V: value, Valid: valid}}
+}
+
+// isZero reports whether value is the zero value of its type, deferring to
+// its IsZero method when it has one, as time.Time does.
+func isZero(value interface{}) bool {
+	if zeroer, ok := value.(interface{ IsZero() bool }); ok {
+		return zeroer.IsZero()
+	}
+	v := reflect.ValueOf(value)
+	return !v.IsValid() || v.IsZero()
+}
+
+// NewNull returns a non-NULL Null holding value.
+func NewNull[T any](value T) Null[T] {
+	return newNull(value, true)
+}
+
+// NullFrom returns a Null holding the value pointed to, or NULL when value is
+// nil.
+func NullFrom[T any](value *T) Null[T] {
+	if value == nil {
+		return Null[T]{}
+	}
+	return newNull(*value, true)
+}
+
+// NullFromZero returns a Null holding value, or NULL when value is the zero
+// value of T.
+func NullFromZero[T any](value T) Null[T] {
+	if isZero(value) {
+		return Null[T]{}
+	}
+	return newNull(value, true)
+}
+
+// ValidateValue //
+func (n Null[T]) ValidateValue() interface{} {
+	if n.Valid {
+		return n.V
+	}
+	return nil
+}
+
+// PtrOrNil //
+func (n Null[T]) PtrOrNil() *T {
+	if n.Valid {
+		s := n.V
+		return &s
+	}
+	return nil
+}
+
+// ValueOrZero returns the value, or the zero value of T when NULL.
+func (n Null[T]) ValueOrZero() T {
+	var zero T
+	return n.ValueOr(zero)
+}
+
+// ValueOr returns the value, or the given default when NULL.
+func (n Null[T]) ValueOr(value T) T {
+	if n.Valid {
+		return n.V
+	}
+	return value
+}
+
+// IsZero reports whether the value is NULL, so that fields tagged
+// `json:",omitzero"` are left out when NULL.
+func (n Null[T]) IsZero() bool {
+	return !n.Valid
+}
+
+// Value converts the value as database/sql would, after normalizing it with
+// normalizeValue.
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	value, err := normalizeValue(n.V)
+	if err != nil {
+		return nil, err
+	}
+	return driver.DefaultParameterConverter.ConvertValue(value)
+}
+
+// normalizeValue prepares value to be written to the database: strings and
+// times go through NormalizeString and NormalizeTime when set, and NaN or
+// infinite floats are rejected with an error wrapping ErrNonFiniteFloat.
+func normalizeValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if NormalizeString != nil {
+			return NormalizeString(v), nil
+		}
+	case time.Time:
+		if NormalizeTime != nil {
+			return NormalizeTime(v), nil
+		}
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("%w: %v", ErrNonFiniteFloat, v)
+		}
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return nil, fmt.Errorf("%w: %v", ErrNonFiniteFloat, v)
+		}
+	}
+	return value, nil
+}
+
+// MarshalJSON //
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if n.Valid {
+		return json.Marshal(n.V)
+	}
+	return json.Marshal(nil)
+}
+
+// UnmarshalJSON //
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	value := new(T)
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if value != nil {
+		n.V = *value
+		n.Valid = true
+	} else {
+		var zero T
+		n.V = zero
+		n.Valid = false
+	}
+	return nil
+}
+
+// MarshalXML //
+func (n Null[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !n.Valid {
+		return marshalXMLNull(e, start)
+	}
+	return e.EncodeElement(n.V, start)
+}
+
+// UnmarshalXML //
+func (n *Null[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var value T
+	if isXMLNil(start) {
+		n.V = value
+		n.Valid = false
+		return d.Skip()
+	}
+	err := d.DecodeElement(&value, &start)
+	if err != nil {
+		return err
+	}
+	n.V = value
+	n.Valid = true
+	return nil
+}
+
+// MarshalXMLAttr //
+func (n Null[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !n.Valid {
+		return xml.Attr{}, nil
+	}
+	text, err := xmlText(n.V)
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: text}, nil
+}
+
+// UnmarshalXMLAttr //
+func (n *Null[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	var value T
+	err := setXMLText(&value, attr.Value)
+	if err != nil {
+		return err
+	}
+	n.V = value
+	n.Valid = true
+	return nil
+}
@@ -0,0 +1,211 @@
+package sqljson_test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+
+	validator "gopkg.in/go-playground/validator.v9"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestValidateValuer(t *testing.T) {
+	Convey("Given a non-null sqljson.Null[int] value", t, func() {
+		ov := sqljson.Null[int]{Null: sql.Null[int]{V: 42, Valid: true}}
+		Convey("When evaluated", func() {
+			ivOut := sqljson.ValidateValuer(reflect.ValueOf(ov))
+			Convey("Then we should get the value", func() {
+				nv, ok := ivOut.(int)
+				So(ok, ShouldBeTrue)
+				So(nv, ShouldEqual, 42)
+			})
+		})
+	})
+	Convey("Given a null sqljson.Null[int] value", t, func() {
+		ov := sqljson.Null[int]{}
+		Convey("When evaluated", func() {
+			ivOut := sqljson.ValidateValuer(reflect.ValueOf(ov))
+			Convey("Then we should get nil", func() {
+				So(ivOut, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a non-null sqljson.NullString value", t, func() {
+		ov := sqljson.NullString{NullString: sql.NullString{String: "dummy", Valid: true}}
+		Convey("When evaluated", func() {
+			ivOut := sqljson.ValidateValuer(reflect.ValueOf(ov))
+			Convey("Then we should get the value", func() {
+				So(ivOut, ShouldEqual, "dummy")
+			})
+		})
+	})
+	Convey("Given a value which is not a sqljson type", t, func() {
+		Convey("When evaluated", func() {
+			ivOut := sqljson.ValidateValuer(reflect.ValueOf(10))
+			Convey("Then we should get nil", func() {
+				So(ivOut, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a validator using ValidateValuer for sqljson.Null[string]", t, func() {
+		type validatorStruct struct {
+			Name sqljson.Null[string] `validate:"required,min=3"`
+		}
+		validate := validator.New()
+		validate.RegisterCustomTypeFunc(sqljson.ValidateValuer, sqljson.Null[string]{})
+		Convey("When I validate a null and a short value", func() {
+			errNull := validate.Struct(&validatorStruct{})
+			errShort := validate.Struct(&validatorStruct{Name: sqljson.Null[string]{Null: sql.Null[string]{V: "ab", Valid: true}}})
+			errValid := validate.Struct(&validatorStruct{Name: sqljson.Null[string]{Null: sql.Null[string]{V: "abc", Valid: true}}})
+			Convey("Then I should get the appropriate results", func() {
+				So(errNull, ShouldNotBeNil)
+				So(errNull.(validator.ValidationErrors)[0].Tag(), ShouldEqual, "required")
+				So(errShort, ShouldNotBeNil)
+				So(errShort.(validator.ValidationErrors)[0].Tag(), ShouldEqual, "min")
+				So(errValid, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestNullMarshalJSON(t *testing.T) {
+	Convey("Given a non-null sqljson.Null[int32] value", t, func() {
+		valueIn := sqljson.Null[int32]{Null: sql.Null[int32]{V: 123, Valid: true}}
+		Convey("When I marshal it", func() {
+			b, err := valueIn.MarshalJSON()
+			Convey("Then I should get the number", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `123`)
+			})
+		})
+	})
+	Convey("Given a null sqljson.Null[int32] value", t, func() {
+		valueIn := sqljson.Null[int32]{}
+		Convey("When I marshal it", func() {
+			b, err := valueIn.MarshalJSON()
+			Convey("Then I should get null", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `null`)
+			})
+		})
+	})
+}
+
+func TestNullUnmarshalJSON(t *testing.T) {
+	Convey("Given a sqljson.Null[int32] value pointer, and a null JSON value", t, func() {
+		n := &sqljson.Null[int32]{Null: sql.Null[int32]{V: 5, Valid: true}}
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := n.UnmarshalJSON([]byte(`null`))
+			Convey("Then I should get a Null value", func() {
+				So(err, ShouldBeNil)
+				So(n.Valid, ShouldBeFalse)
+				So(n.V, ShouldEqual, 0)
+			})
+		})
+	})
+	Convey("Given a sqljson.Null[int32] value pointer, and a number JSON value", t, func() {
+		n := &sqljson.Null[int32]{}
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := n.UnmarshalJSON([]byte(`77`))
+			Convey("Then I should get a not-Null value", func() {
+				So(err, ShouldBeNil)
+				So(n.Valid, ShouldBeTrue)
+				So(n.V, ShouldEqual, 77)
+			})
+		})
+	})
+	Convey("Given a sqljson.Null[int32] value pointer, and a string JSON value", t, func() {
+		n := &sqljson.Null[int32]{}
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := n.UnmarshalJSON([]byte(`"77"`))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "cannot unmarshal string")
+				So(n.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestNullScan(t *testing.T) {
+	Convey("Given a sqljson.Null[int32] value pointer", t, func() {
+		n := &sqljson.Null[int32]{}
+		Convey("When I scan a driver value and then nil", func() {
+			errValue := n.Scan(int64(12))
+			valid, value := n.Valid, n.V
+			errNil := n.Scan(nil)
+			Convey("Then I should get the value and then NULL", func() {
+				So(errValue, ShouldBeNil)
+				So(valid, ShouldBeTrue)
+				So(value, ShouldEqual, 12)
+				So(errNil, ShouldBeNil)
+				So(n.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestNullPtrOrNil(t *testing.T) {
+	Convey("Given a non-null sqljson.Null[string] value", t, func() {
+		value := sqljson.Null[string]{Null: sql.Null[string]{V: "dummy", Valid: true}}
+		Convey("When we get the PtrOrNil", func() {
+			res := value.PtrOrNil()
+			Convey("Then we get the value", func() {
+				So(res, ShouldNotBeNil)
+				So(*res, ShouldEqual, "dummy")
+			})
+		})
+	})
+	Convey("Given a null sqljson.Null[string] value", t, func() {
+		value := sqljson.Null[string]{}
+		Convey("When we get the PtrOrNil", func() {
+			res := value.PtrOrNil()
+			Convey("Then we get nil", func() {
+				So(res, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestNullConstructors(t *testing.T) {
+	Convey("Given a value, a pointer to it, a nil pointer and the zero value", t, func() {
+		value := "dummy"
+		Convey("When I build sqljson.Null values from them", func() {
+			fromValue := sqljson.NewNull(value)
+			fromPtr := sqljson.NullFrom(&value)
+			fromNil := sqljson.NullFrom[string](nil)
+			fromZero := sqljson.NullFromZero("")
+			fromNonZero := sqljson.NullFromZero(value)
+			Convey("Then only the nil pointer and the zero value should be NULL", func() {
+				So(fromValue.Valid, ShouldBeTrue)
+				So(fromValue.V, ShouldEqual, value)
+				So(fromPtr.Valid, ShouldBeTrue)
+				So(fromPtr.V, ShouldEqual, value)
+				So(fromNil.Valid, ShouldBeFalse)
+				So(fromZero.Valid, ShouldBeFalse)
+				So(fromNonZero.Valid, ShouldBeTrue)
+			})
+			Convey("Then ValueOrZero and ValueOr should fall back only when NULL", func() {
+				So(fromValue.ValueOrZero(), ShouldEqual, value)
+				So(fromValue.ValueOr("default"), ShouldEqual, value)
+				So(fromNil.ValueOrZero(), ShouldEqual, "")
+				So(fromNil.ValueOr("default"), ShouldEqual, "default")
+			})
+		})
+	})
+	Convey("Given zero values of a struct and a slice type", t, func() {
+		Convey("When I build sqljson.Null values from them with NullFromZero", func() {
+			fromStruct := sqljson.NullFromZero(struct{ A int }{})
+			fromSlice := sqljson.NullFromZero([]int(nil))
+			fromEmptySlice := sqljson.NullFromZero([]int{})
+			Convey("Then only the actual zero values should be NULL", func() {
+				So(fromStruct.Valid, ShouldBeFalse)
+				So(fromSlice.Valid, ShouldBeFalse)
+				So(fromEmptySlice.Valid, ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -0,0 +1,101 @@
+package sqljson
+
+import "encoding/xml"
+
+// Optional is a Null that also records whether it was set at all, so that a
+// JSON key sent as null ("clear this column") can be told apart from a key
+// that was never sent ("leave this column unchanged").
+type Optional[T any] struct {
+	Null[T]
+	Set bool
+}
+
+// optional is implemented by types recording whether they were set.
+type optional interface {
+	IsSet() bool
+}
+
+// NewOptional returns a set, non-NULL Optional holding value.
+func NewOptional[T any](value T) Optional[T] {
+	return Optional[T]{Null: NewNull(value), Set: true}
+}
+
+// OptionalFrom returns a set Optional holding the value pointed to, or NULL
+// when value is nil.
+func OptionalFrom[T any](value *T) Optional[T] {
+	return Optional[T]{Null: NullFrom(value), Set: true}
+}
+
+// OptionalFromZero returns a set Optional holding value, or NULL when value
+// is the zero value of T.
+func OptionalFromZero[T any](value T) Optional[T] {
+	return Optional[T]{Null: NullFromZero(value), Set: true}
+}
+
+// IsSet //
+func (o Optional[T]) IsSet() bool {
+	return o.Set
+}
+
+// IsZero reports whether the Optional was not set, so that fields tagged
+// `json:",omitzero"` are left out unless set. A set NULL is kept, as it
+// means "clear this column".
+func (o Optional[T]) IsZero() bool {
+	return !o.Set
+}
+
+// Scan //
+func (o *Optional[T]) Scan(value interface{}) error {
+	err := o.Null.Scan(value)
+	if err != nil {
+		return err
+	}
+	o.Set = true
+	return nil
+}
+
+// UnmarshalJSON //
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	err := o.Null.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	o.Set = true
+	return nil
+}
+
+// MarshalXML leaves the element out when the Optional was not set.
+func (o Optional[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !o.Set {
+		return nil
+	}
+	return o.Null.MarshalXML(e, start)
+}
+
+// UnmarshalXML //
+func (o *Optional[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	err := o.Null.UnmarshalXML(d, start)
+	if err != nil {
+		return err
+	}
+	o.Set = true
+	return nil
+}
+
+// MarshalXMLAttr leaves the attribute out when the Optional was not set.
+func (o Optional[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !o.Set {
+		return xml.Attr{}, nil
+	}
+	return o.Null.MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr //
+func (o *Optional[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	err := o.Null.UnmarshalXMLAttr(attr)
+	if err != nil {
+		return err
+	}
+	o.Set = true
+	return nil
+}
@@ -0,0 +1,98 @@
+package sqljson_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOptionalUnmarshalJSON(t *testing.T) {
+	type patchStruct struct {
+		Name      sqljson.Optional[string] `json:"name"`
+		Followers sqljson.Optional[int64]  `json:"followers"`
+		IsAdmin   sqljson.Optional[bool]   `json:"is_admin"`
+	}
+	Convey("Given a JSON string with a value, an explicit null and a missing key", t, func() {
+		strJSON := []byte(`{"name": "Gabriel", "followers": null}`)
+		Convey("When I unmarshal it onto a struct using sqljson.Optional", func() {
+			patch := &patchStruct{}
+			err := json.Unmarshal(strJSON, patch)
+			Convey("Then I should be able to tell all three apart", func() {
+				So(err, ShouldBeNil)
+				So(patch.Name.Set, ShouldBeTrue)
+				So(patch.Name.Valid, ShouldBeTrue)
+				So(patch.Name.V, ShouldEqual, "Gabriel")
+				So(patch.Followers.Set, ShouldBeTrue)
+				So(patch.Followers.Valid, ShouldBeFalse)
+				So(patch.IsAdmin.Set, ShouldBeFalse)
+				So(patch.IsAdmin.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a JSON string with an invalid value", t, func() {
+		strJSON := []byte(`{"followers": "many"}`)
+		Convey("When I unmarshal it onto a struct using sqljson.Optional", func() {
+			patch := &patchStruct{}
+			err := json.Unmarshal(strJSON, patch)
+			Convey("Then I should get an error and the field should not be set", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "cannot unmarshal string")
+				So(patch.Followers.Set, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestOptionalMarshalJSON(t *testing.T) {
+	Convey("Given a set and an unset sqljson.Optional value", t, func() {
+		set := sqljson.Optional[int64]{}
+		_ = set.UnmarshalJSON([]byte(`10`))
+		unset := sqljson.Optional[int64]{}
+		Convey("When I marshal them", func() {
+			bSet, errSet := json.Marshal(set)
+			bUnset, errUnset := json.Marshal(unset)
+			Convey("Then I should get the value and null", func() {
+				So(errSet, ShouldBeNil)
+				So(string(bSet), ShouldEqual, `10`)
+				So(errUnset, ShouldBeNil)
+				So(string(bUnset), ShouldEqual, `null`)
+			})
+		})
+	})
+}
+
+func TestOptionalScan(t *testing.T) {
+	Convey("Given a sqljson.Optional value pointer", t, func() {
+		o := &sqljson.Optional[string]{}
+		Convey("When I scan a NULL into it", func() {
+			err := o.Scan(nil)
+			Convey("Then it should be set but not valid", func() {
+				So(err, ShouldBeNil)
+				So(o.IsSet(), ShouldBeTrue)
+				So(o.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestOptionalConstructors(t *testing.T) {
+	Convey("Given a value, a nil pointer and the zero value", t, func() {
+		Convey("When I build sqljson.Optional values from them", func() {
+			fromValue := sqljson.NewOptional(int64(10))
+			fromNil := sqljson.OptionalFrom[int64](nil)
+			fromZero := sqljson.OptionalFromZero(int64(0))
+			Convey("Then they should all be set, and only the value be valid", func() {
+				So(fromValue.Set, ShouldBeTrue)
+				So(fromValue.Valid, ShouldBeTrue)
+				So(fromValue.ValueOrZero(), ShouldEqual, 10)
+				So(fromNil.Set, ShouldBeTrue)
+				So(fromNil.Valid, ShouldBeFalse)
+				So(fromZero.Set, ShouldBeTrue)
+				So(fromZero.Valid, ShouldBeFalse)
+				So(fromZero.ValueOr(-1), ShouldEqual, -1)
+			})
+		})
+	})
+}
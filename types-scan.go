@@ -0,0 +1,226 @@
+package sqljson
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scanTimeLayouts are the layouts tried, after TimeLayout, when scanning a
+// time from text. They cover the formats of the common drivers.
+var scanTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// driverValue converts value to a driver.Value when it is not one already,
+// as drivers such as go-sqlmock pass plain Go values like int through.
+func driverValue(value interface{}) interface{} {
+	if driver.IsValue(value) {
+		return value
+	}
+	if v, err := driver.DefaultParameterConverter.ConvertValue(value); err == nil {
+		return v
+	}
+	return value
+}
+
+// scanText returns value as trimmed text when it is a []byte or a string.
+func scanText(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case []byte:
+		return strings.TrimSpace(string(v)), true
+	case string:
+		return strings.TrimSpace(v), true
+	}
+	return "", false
+}
+
+// scanBool converts the common driver representations of a boolean: bool,
+// the numbers 0 and 1, and texts such as "t"/"f", "Y"/"N" or "on"/"off".
+func scanBool(value interface{}, into string) (bool, error) {
+	value = driverValue(value)
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case int64:
+		if v == 0 || v == 1 {
+			return v == 1, nil
+		}
+		return false, fmt.Errorf("sqljson: cannot scan %d into %s: value out of range", v, into)
+	case float64:
+		if v == 0 || v == 1 {
+			return v == 1, nil
+		}
+		return false, fmt.Errorf("sqljson: cannot scan %v into %s: value out of range", v, into)
+	}
+	text, ok := scanText(value)
+	if !ok {
+		return false, fmt.Errorf("sqljson: cannot scan %T into %s", value, into)
+	}
+	switch strings.ToLower(text) {
+	case "1", "t", "true", "y", "yes", "on":
+		return true, nil
+	case "0", "f", "false", "n", "no", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("sqljson: cannot scan %q into %s", text, into)
+}
+
+// scanInt converts the common driver representations of an integer within
+// [min, max]: integers, integral floats, booleans and decimal texts such as
+// "12" or "12.00".
+func scanInt(value interface{}, min, max int64, into string) (int64, error) {
+	value = driverValue(value)
+	switch v := value.(type) {
+	case int64:
+		if v < min || v > max {
+			return 0, fmt.Errorf("sqljson: cannot scan %d into %s: value out of range", v, into)
+		}
+		return v, nil
+	case uint64:
+		if v > uint64(max) {
+			return 0, fmt.Errorf("sqljson: cannot scan %d into %s: value out of range", v, into)
+		}
+		return int64(v), nil
+	case float64:
+		if v != math.Trunc(v) || v < float64(min) || v >= -float64(math.MinInt64) || int64(v) > max {
+			return 0, fmt.Errorf("sqljson: cannot scan %v into %s: value out of range", v, into)
+		}
+		return int64(v), nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	text, ok := scanText(value)
+	if !ok {
+		return 0, fmt.Errorf("sqljson: cannot scan %T into %s", value, into)
+	}
+	i, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		f, errFloat := strconv.ParseFloat(text, 64)
+		if errFloat != nil {
+			return 0, fmt.Errorf("sqljson: cannot scan %q into %s: %s", text, into, err.(*strconv.NumError).Err)
+		}
+		return scanInt(f, min, max, into)
+	}
+	return scanInt(i, min, max, into)
+}
+
+// scanUint converts the common driver representations of an unsigned
+// integer: non-negative integers, integral floats, booleans and decimal
+// texts such as "12" or "12.00".
+func scanUint(value interface{}, into string) (uint64, error) {
+	value = driverValue(value)
+	switch v := value.(type) {
+	case int64:
+		if v < 0 {
+			return 0, fmt.Errorf("sqljson: cannot scan %d into %s: value out of range", v, into)
+		}
+		return uint64(v), nil
+	case uint64:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) || v < 0 || v >= math.MaxUint64 {
+			return 0, fmt.Errorf("sqljson: cannot scan %v into %s: value out of range", v, into)
+		}
+		return uint64(v), nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	text, ok := scanText(value)
+	if !ok {
+		return 0, fmt.Errorf("sqljson: cannot scan %T into %s", value, into)
+	}
+	u, err := strconv.ParseUint(text, 10, 64)
+	if err != nil {
+		f, errFloat := strconv.ParseFloat(text, 64)
+		if errFloat != nil || err.(*strconv.NumError).Err == strconv.ErrRange {
+			return 0, fmt.Errorf("sqljson: cannot scan %q into %s: %s", text, into, err.(*strconv.NumError).Err)
+		}
+		return scanUint(f, into)
+	}
+	return u, nil
+}
+
+// scanFloat converts the common driver representations of a float: floats,
+// integers and decimal texts.
+func scanFloat(value interface{}, into string) (float64, error) {
+	value = driverValue(value)
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int64:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	}
+	text, ok := scanText(value)
+	if !ok {
+		return 0, fmt.Errorf("sqljson: cannot scan %T into %s", value, into)
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("sqljson: cannot scan %q into %s: %s", text, into, err.(*strconv.NumError).Err)
+	}
+	return f, nil
+}
+
+// scanString converts the common driver representations of a string:
+// texts, and numbers, booleans and times formatted as database/sql does.
+func scanString(value interface{}, into string) (string, error) {
+	value = driverValue(value)
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	}
+	return "", fmt.Errorf("sqljson: cannot scan %T into %s", value, into)
+}
+
+// scanTime converts the common driver representations of a time: times,
+// texts in TimeLayout or in the formats of the common drivers, and integers
+// as Unix seconds.
+func scanTime(value interface{}, into string) (time.Time, error) {
+	value = driverValue(value)
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case int64:
+		return time.Unix(v, 0).UTC(), nil
+	}
+	text, ok := scanText(value)
+	if !ok {
+		return time.Time{}, fmt.Errorf("sqljson: cannot scan %T into %s", value, into)
+	}
+	for _, layout := range append([]string{TimeLayout}, scanTimeLayouts...) {
+		t, err := time.Parse(layout, text)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("sqljson: cannot scan %q into %s", text, into)
+}
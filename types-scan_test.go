@@ -0,0 +1,171 @@
+package sqljson_test
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rhaseven7h/sqljson"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// scanCase is a driver value, and what scanning it into dest should give:
+// the wrapped value, NULL when want is nil, or an error containing err.
+type scanCase struct {
+	in   interface{}
+	want interface{}
+	err  string
+}
+
+type scanTarget interface {
+	sql.Scanner
+	ValidateValue() interface{}
+}
+
+func runScanMatrix(t *testing.T, name string, newDest func() scanTarget, cases []scanCase) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	for _, c := range cases {
+		c := c
+		Convey(fmt.Sprintf("Given a sql mock returning %#v", c.in), t, func() {
+			mock.
+				ExpectQuery(`SELECT a FROM matrix`).
+				WillReturnRows(sqlmock.NewRows([]string{"a"}).AddRow(c.in))
+			Convey("When I scan it into a "+name, func() {
+				dest := newDest()
+				dbErr := db.QueryRow(`SELECT a FROM matrix`).Scan(dest)
+				if c.err != "" {
+					Convey("Then I should get an error", func() {
+						So(dbErr, ShouldNotBeNil)
+						So(dbErr.Error(), ShouldContainSubstring, c.err)
+					})
+					return
+				}
+				Convey(fmt.Sprintf("Then I should get %#v", c.want), func() {
+					So(dbErr, ShouldBeNil)
+					if want, ok := c.want.(time.Time); ok {
+						So(dest.ValidateValue().(time.Time).Equal(want), ShouldBeTrue)
+						return
+					}
+					So(dest.ValidateValue(), ShouldResemble, c.want)
+				})
+			})
+		})
+	}
+}
+
+func TestScanMatrixBool(t *testing.T) {
+	runScanMatrix(t, "NullBool", func() scanTarget { return &sqljson.NullBool{} }, []scanCase{
+		{in: nil, want: nil},
+		{in: true, want: true},
+		{in: int64(1), want: true},
+		{in: int64(0), want: false},
+		{in: 1, want: true},
+		{in: []byte("1"), want: true},
+		{in: "t", want: true},
+		{in: "F", want: false},
+		{in: "Y", want: true},
+		{in: "n", want: false},
+		{in: []byte("TRUE"), want: true},
+		{in: " yes ", want: true},
+		{in: "off", want: false},
+		{in: int64(2), err: "cannot scan 2 into NullBool: value out of range"},
+		{in: "maybe", err: `cannot scan "maybe" into NullBool`},
+		{in: time.Time{}, err: "cannot scan time.Time into NullBool"},
+	})
+}
+
+func TestScanMatrixInt(t *testing.T) {
+	runScanMatrix(t, "NullInt64", func() scanTarget { return &sqljson.NullInt64{} }, []scanCase{
+		{in: nil, want: nil},
+		{in: int64(-42), want: int64(-42)},
+		{in: 42, want: int64(42)},
+		{in: []byte("42"), want: int64(42)},
+		{in: " 42 ", want: int64(42)},
+		{in: "42.00", want: int64(42)},
+		{in: 42.0, want: int64(42)},
+		{in: true, want: int64(1)},
+		{in: 42.5, err: "cannot scan 42.5 into NullInt64: value out of range"},
+		{in: "9223372036854775808", err: "value out of range"},
+		{in: "forty", err: `cannot scan "forty" into NullInt64: invalid syntax`},
+	})
+	runScanMatrix(t, "NullInt32", func() scanTarget { return &sqljson.NullInt32{} }, []scanCase{
+		{in: []byte("-2147483648"), want: int32(-2147483648)},
+		{in: int64(2147483648), err: "cannot scan 2147483648 into NullInt32: value out of range"},
+	})
+	runScanMatrix(t, "NullInt16", func() scanTarget { return &sqljson.NullInt16{} }, []scanCase{
+		{in: "32767", want: int16(32767)},
+		{in: int64(-32769), err: "cannot scan -32769 into NullInt16: value out of range"},
+	})
+	runScanMatrix(t, "NullByte", func() scanTarget { return &sqljson.NullByte{} }, []scanCase{
+		{in: []byte("255"), want: byte(255)},
+		{in: int64(-1), err: "cannot scan -1 into NullByte: value out of range"},
+		{in: int64(256), err: "cannot scan 256 into NullByte: value out of range"},
+	})
+}
+
+func TestScanMatrixUint(t *testing.T) {
+	runScanMatrix(t, "NullUint64", func() scanTarget { return &sqljson.NullUint64{} }, []scanCase{
+		{in: nil, want: nil},
+		{in: int64(42), want: uint64(42)},
+		{in: 42, want: uint64(42)},
+		{in: uint64(18446744073709551615), want: uint64(18446744073709551615)},
+		{in: []byte("18446744073709551615"), want: uint64(18446744073709551615)},
+		{in: " 12 ", want: uint64(12)},
+		{in: "12.00", want: uint64(12)},
+		{in: 12.0, want: uint64(12)},
+		{in: true, want: uint64(1)},
+		{in: int64(-1), err: "cannot scan -1 into NullUint64: value out of range"},
+		{in: "-1", err: `cannot scan -1 into NullUint64: value out of range`},
+		{in: "18446744073709551616", err: "value out of range"},
+		{in: 1.5, err: "cannot scan 1.5 into NullUint64: value out of range"},
+		{in: "twelve", err: `cannot scan "twelve" into NullUint64: invalid syntax`},
+	})
+}
+
+func TestScanMatrixFloat(t *testing.T) {
+	runScanMatrix(t, "NullFloat64", func() scanTarget { return &sqljson.NullFloat64{} }, []scanCase{
+		{in: nil, want: nil},
+		{in: 123.45, want: 123.45},
+		{in: float32(1.5), want: 1.5},
+		{in: int64(12), want: 12.0},
+		{in: []byte("123.45"), want: 123.45},
+		{in: "-1e3", want: -1000.0},
+		{in: "12,5", err: `cannot scan "12,5" into NullFloat64: invalid syntax`},
+		{in: true, err: "cannot scan bool into NullFloat64"},
+	})
+}
+
+func TestScanMatrixString(t *testing.T) {
+	runScanMatrix(t, "NullString", func() scanTarget { return &sqljson.NullString{} }, []scanCase{
+		{in: nil, want: nil},
+		{in: "dummy", want: "dummy"},
+		{in: []byte(" dummy "), want: " dummy "},
+		{in: int64(42), want: "42"},
+		{in: 12.5, want: "12.5"},
+		{in: true, want: "true"},
+		{in: time.Date(2017, time.May, 1, 10, 20, 30, 0, time.UTC), want: "2017-05-01T10:20:30Z"},
+	})
+}
+
+func TestScanMatrixTime(t *testing.T) {
+	tm := time.Date(2017, time.May, 1, 10, 20, 30, 0, time.UTC)
+	runScanMatrix(t, "NullTime", func() scanTarget { return &sqljson.NullTime{} }, []scanCase{
+		{in: nil, want: nil},
+		{in: tm, want: tm},
+		{in: "2017-05-01T10:20:30Z", want: tm},
+		{in: []byte("2017-05-01 10:20:30"), want: tm},
+		{in: "2017-05-01 10:20:30+00", want: tm},
+		{in: "2017-05-01", want: time.Date(2017, time.May, 1, 0, 0, 0, 0, time.UTC)},
+		{in: tm.Unix(), want: tm},
+		{in: "01/05/2017", err: `cannot scan "01/05/2017" into NullTime`},
+		{in: 12.5, err: "cannot scan float64 into NullTime"},
+	})
+}
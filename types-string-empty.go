@@ -0,0 +1,292 @@
+package sqljson
+
+import (
+	"database/sql/driver"
+	"encoding/xml"
+	"strings"
+)
+
+// EmptyNullString is a NullString that treats an empty string as NULL when
+// decoding JSON, scanning, writing to the database and validating, so NULL
+// is the only representation of "no value".
+type EmptyNullString struct {
+	NullString
+}
+
+// BlankNullString is like EmptyNullString, but also treats strings made only
+// of whitespace as NULL.
+type BlankNullString struct {
+	NullString
+}
+
+// NewEmptyNullString returns NULL for an empty string.
+func NewEmptyNullString(value string) EmptyNullString {
+	ns := EmptyNullString{NullString: NewNullString(value)}
+	ns.NullString = ns.normalized()
+	return ns
+}
+
+// EmptyNullStringFrom returns NULL for a nil pointer or an empty string.
+func EmptyNullStringFrom(value *string) EmptyNullString {
+	if value == nil {
+		return EmptyNullString{}
+	}
+	return NewEmptyNullString(*value)
+}
+
+// EmptyNullStringFromZero is NewEmptyNullString, the zero value being the
+// empty string.
+func EmptyNullStringFromZero(value string) EmptyNullString {
+	return NewEmptyNullString(value)
+}
+
+// NewBlankNullString returns NULL for a blank string.
+func NewBlankNullString(value string) BlankNullString {
+	ns := BlankNullString{NullString: NewNullString(value)}
+	ns.NullString = ns.normalized()
+	return ns
+}
+
+// BlankNullStringFrom returns NULL for a nil pointer or a blank string.
+func BlankNullStringFrom(value *string) BlankNullString {
+	if value == nil {
+		return BlankNullString{}
+	}
+	return NewBlankNullString(*value)
+}
+
+// BlankNullStringFromZero is NewBlankNullString, the zero value being
+// blank.
+func BlankNullStringFromZero(value string) BlankNullString {
+	return NewBlankNullString(value)
+}
+
+func (ns EmptyNullString) normalized() NullString {
+	if ns.Valid && ns.String == "" {
+		return NullString{}
+	}
+	return ns.NullString
+}
+
+func (ns BlankNullString) normalized() NullString {
+	if ns.Valid && strings.TrimSpace(ns.String) == "" {
+		return NullString{}
+	}
+	return ns.NullString
+}
+
+// ValidateValue //
+func (ns EmptyNullString) ValidateValue() interface{} {
+	return ns.normalized().ValidateValue()
+}
+
+// StringPtrOrNil //
+func (ns EmptyNullString) StringPtrOrNil() *string {
+	return ns.normalized().StringPtrOrNil()
+}
+
+// ValueOrZero //
+func (ns EmptyNullString) ValueOrZero() string {
+	return ns.normalized().ValueOrZero()
+}
+
+// ValueOr //
+func (ns EmptyNullString) ValueOr(value string) string {
+	return ns.normalized().ValueOr(value)
+}
+
+// IsZero //
+func (ns EmptyNullString) IsZero() bool {
+	return ns.normalized().IsZero()
+}
+
+// MarshalJSON //
+func (ns EmptyNullString) MarshalJSON() ([]byte, error) {
+	return ns.normalized().MarshalJSON()
+}
+
+// UnmarshalJSON //
+func (ns *EmptyNullString) UnmarshalJSON(data []byte) error {
+	err := ns.NullString.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	ns.NullString = ns.normalized()
+	return nil
+}
+
+// MarshalText //
+func (ns EmptyNullString) MarshalText() ([]byte, error) {
+	return ns.normalized().MarshalText()
+}
+
+// UnmarshalText //
+func (ns *EmptyNullString) UnmarshalText(data []byte) error {
+	err := ns.NullString.UnmarshalText(data)
+	if err != nil {
+		return err
+	}
+	ns.NullString = ns.normalized()
+	return nil
+}
+
+// MarshalXML //
+func (ns EmptyNullString) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return ns.normalized().MarshalXML(e, start)
+}
+
+// UnmarshalXML //
+func (ns *EmptyNullString) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	err := ns.NullString.UnmarshalXML(d, start)
+	if err != nil {
+		return err
+	}
+	ns.NullString = ns.normalized()
+	return nil
+}
+
+// MarshalXMLAttr //
+func (ns EmptyNullString) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return ns.normalized().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr //
+func (ns *EmptyNullString) UnmarshalXMLAttr(attr xml.Attr) error {
+	err := ns.NullString.UnmarshalXMLAttr(attr)
+	if err != nil {
+		return err
+	}
+	ns.NullString = ns.normalized()
+	return nil
+}
+
+// Scan //
+func (ns *EmptyNullString) Scan(value interface{}) error {
+	err := ns.NullString.Scan(value)
+	if err != nil {
+		return err
+	}
+	ns.NullString = ns.normalized()
+	return nil
+}
+
+// Value writes NULL when the string is empty after NormalizeString.
+func (ns EmptyNullString) Value() (driver.Value, error) {
+	return nullStringValue(ns.NullString, func(s string) bool { return s == "" })
+}
+
+// ValidateValue //
+func (ns BlankNullString) ValidateValue() interface{} {
+	return ns.normalized().ValidateValue()
+}
+
+// StringPtrOrNil //
+func (ns BlankNullString) StringPtrOrNil() *string {
+	return ns.normalized().StringPtrOrNil()
+}
+
+// ValueOrZero //
+func (ns BlankNullString) ValueOrZero() string {
+	return ns.normalized().ValueOrZero()
+}
+
+// ValueOr //
+func (ns BlankNullString) ValueOr(value string) string {
+	return ns.normalized().ValueOr(value)
+}
+
+// IsZero //
+func (ns BlankNullString) IsZero() bool {
+	return ns.normalized().IsZero()
+}
+
+// MarshalJSON //
+func (ns BlankNullString) MarshalJSON() ([]byte, error) {
+	return ns.normalized().MarshalJSON()
+}
+
+// UnmarshalJSON //
+func (ns *BlankNullString) UnmarshalJSON(data []byte) error {
+	err := ns.NullString.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	ns.NullString = ns.normalized()
+	return nil
+}
+
+// MarshalText //
+func (ns BlankNullString) MarshalText() ([]byte, error) {
+	return ns.normalized().MarshalText()
+}
+
+// UnmarshalText //
+func (ns *BlankNullString) UnmarshalText(data []byte) error {
+	err := ns.NullString.UnmarshalText(data)
+	if err != nil {
+		return err
+	}
+	ns.NullString = ns.normalized()
+	return nil
+}
+
+// MarshalXML //
+func (ns BlankNullString) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return ns.normalized().MarshalXML(e, start)
+}
+
+// UnmarshalXML //
+func (ns *BlankNullString) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	err := ns.NullString.UnmarshalXML(d, start)
+	if err != nil {
+		return err
+	}
+	ns.NullString = ns.normalized()
+	return nil
+}
+
+// MarshalXMLAttr //
+func (ns BlankNullString) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return ns.normalized().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr //
+func (ns *BlankNullString) UnmarshalXMLAttr(attr xml.Attr) error {
+	err := ns.NullString.UnmarshalXMLAttr(attr)
+	if err != nil {
+		return err
+	}
+	ns.NullString = ns.normalized()
+	return nil
+}
+
+// Scan //
+func (ns *BlankNullString) Scan(value interface{}) error {
+	err := ns.NullString.Scan(value)
+	if err != nil {
+		return err
+	}
+	ns.NullString = ns.normalized()
+	return nil
+}
+
+// Value writes NULL when the string is blank after NormalizeString.
+func (ns BlankNullString) Value() (driver.Value, error) {
+	return nullStringValue(ns.NullString, func(s string) bool { return strings.TrimSpace(s) == "" })
+}
+
+// nullStringValue normalizes the string as NullString.Value does, then
+// writes NULL when isNull reports the normalized string as no value.
+func nullStringValue(ns NullString, isNull func(string) bool) (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	value, err := normalizeValue(ns.String)
+	if err != nil {
+		return nil, err
+	}
+	if isNull(value.(string)) {
+		return nil, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,199 @@
+package sqljson_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	validator "gopkg.in/go-playground/validator.v9"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestEmptyNullStringUnmarshalJSON(t *testing.T) {
+	Convey("Given a sqljson.EmptyNullString value pointer", t, func() {
+		ns := &sqljson.EmptyNullString{}
+		Convey("When I Unmarshal an empty string", func() {
+			err := ns.UnmarshalJSON([]byte(`""`))
+			Convey("Then I should get a Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+		Convey("When I Unmarshal a whitespace-only string", func() {
+			err := ns.UnmarshalJSON([]byte(`"  "`))
+			Convey("Then I should keep the string", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeTrue)
+				So(ns.String, ShouldEqual, "  ")
+			})
+		})
+		Convey("When I Unmarshal a non-string value", func() {
+			err := ns.UnmarshalJSON([]byte(`10`))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "cannot unmarshal number")
+			})
+		})
+	})
+}
+
+func TestBlankNullStringUnmarshalJSON(t *testing.T) {
+	Convey("Given a sqljson.BlankNullString value pointer", t, func() {
+		ns := &sqljson.BlankNullString{}
+		Convey("When I Unmarshal a whitespace-only string", func() {
+			err := ns.UnmarshalJSON([]byte(`" \t "`))
+			Convey("Then I should get a Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+				So(ns.String, ShouldEqual, "")
+			})
+		})
+		Convey("When I Unmarshal a padded string", func() {
+			err := ns.UnmarshalJSON([]byte(`" Gabriel "`))
+			Convey("Then I should keep the string untouched", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeTrue)
+				So(ns.String, ShouldEqual, " Gabriel ")
+			})
+		})
+	})
+}
+
+func TestEmptyNullStringMarshalJSON(t *testing.T) {
+	Convey("Given sqljson.EmptyNullString and BlankNullString values holding empty strings", t, func() {
+		empty := sqljson.EmptyNullString{NullString: sqljson.NullString{NullString: sql.NullString{String: "", Valid: true}}}
+		blank := sqljson.BlankNullString{NullString: sqljson.NullString{NullString: sql.NullString{String: " ", Valid: true}}}
+		Convey("When I marshal them", func() {
+			b, err := json.Marshal([]interface{}{empty, blank})
+			Convey("Then I should get nulls", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `[null,null]`)
+			})
+		})
+	})
+}
+
+func TestEmptyNullStringSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	Convey("Given a sql mock returning empty and blank strings", t, func() {
+		mock.
+			ExpectQuery(`SELECT nickname, bio FROM suppliers`).
+			WillReturnRows(sqlmock.NewRows([]string{"nickname", "bio"}).AddRow("", "   "))
+		Convey("When I query a row and scan it", func() {
+			nickname := sqljson.EmptyNullString{}
+			bio := sqljson.BlankNullString{}
+			dbErr := db.QueryRow(`SELECT nickname, bio FROM suppliers`).Scan(&nickname, &bio)
+			Convey("Then I should get Null values", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+				So(nickname.Valid, ShouldBeFalse)
+				So(bio.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given sqljson.EmptyNullString and BlankNullString values holding empty strings", t, func() {
+		nickname := sqljson.EmptyNullString{NullString: sqljson.NullString{NullString: sql.NullString{String: "", Valid: true}}}
+		bio := sqljson.BlankNullString{NullString: sqljson.NullString{NullString: sql.NullString{String: "\n", Valid: true}}}
+		Convey("When I write them to the database", func() {
+			mock.
+				ExpectExec(`UPDATE suppliers SET nickname = \?, bio = \?`).
+				WithArgs(nil, nil).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			_, dbErr := db.Exec(`UPDATE suppliers SET nickname = ?, bio = ?`, nickname, bio)
+			Convey("Then they should be written as NULL", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given sqljson.NormalizeString set, and sqljson.EmptyNullString values holding blank and padded strings", t, func() {
+		sqljson.NormalizeString = strings.TrimSpace
+		Reset(func() { sqljson.NormalizeString = nil })
+		nickname := sqljson.EmptyNullString{NullString: sqljson.NewNullString("  ")}
+		bio := sqljson.EmptyNullString{NullString: sqljson.NewNullString(" hi ")}
+		Convey("When I write them to the database", func() {
+			mock.
+				ExpectExec(`UPDATE suppliers SET nickname = \?, bio = \?`).
+				WithArgs(nil, "hi").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			_, dbErr := db.Exec(`UPDATE suppliers SET nickname = ?, bio = ?`, nickname, bio)
+			Convey("Then the empty normalized string should be written as NULL", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestEmptyNullStringValidator(t *testing.T) {
+	Convey("Given a validator and a struct holding an empty sqljson.EmptyNullString", t, func() {
+		validate := validator.New()
+		So(sqljson.RegisterValidator(validate), ShouldBeNil)
+		s := &struct {
+			Nickname sqljson.EmptyNullString `validate:"required"`
+		}{
+			Nickname: sqljson.EmptyNullString{NullString: sqljson.NullString{NullString: sql.NullString{String: "", Valid: true}}},
+		}
+		Convey("When I validate it", func() {
+			err := validate.Struct(s)
+			Convey("Then it should be reported as missing", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(validator.ValidationErrors)[0].Tag(), ShouldEqual, "required")
+			})
+		})
+	})
+}
+
+func TestBlankNullStringText(t *testing.T) {
+	Convey("Given sqljson.NullText set to a marker, and a sqljson.BlankNullString value pointer", t, func() {
+		nullText := sqljson.NullText
+		sqljson.NullText = "null"
+		Reset(func() { sqljson.NullText = nullText })
+		ns := &sqljson.BlankNullString{}
+		Convey("When I Unmarshal a blank text and marshal it back", func() {
+			err := ns.UnmarshalText([]byte("  "))
+			b, errMarshal := ns.MarshalText()
+			Convey("Then it should be NULL both ways", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+				So(errMarshal, ShouldBeNil)
+				So(string(b), ShouldEqual, "null")
+			})
+		})
+	})
+}
+
+func TestEmptyNullStringConstructors(t *testing.T) {
+	Convey("Given a string, a blank string, pointers to them and a nil pointer", t, func() {
+		value, blank := "a", " "
+		Convey("When I build sqljson.EmptyNullString and BlankNullString values from them", func() {
+			empty := []sqljson.EmptyNullString{
+				sqljson.NewEmptyNullString(value), sqljson.EmptyNullStringFrom(&value),
+				sqljson.NewEmptyNullString(""), sqljson.EmptyNullStringFrom(nil), sqljson.EmptyNullStringFromZero(""),
+				sqljson.NewEmptyNullString(blank),
+			}
+			blanks := []sqljson.BlankNullString{
+				sqljson.NewBlankNullString(value), sqljson.BlankNullStringFrom(&value),
+				sqljson.NewBlankNullString(blank), sqljson.BlankNullStringFrom(&blank), sqljson.BlankNullStringFromZero(""),
+			}
+			Convey("Then empty and blank strings should be NULL, as each type treats them", func() {
+				So(empty[0].Valid && empty[1].Valid, ShouldBeTrue)
+				So(empty[0].String, ShouldEqual, "a")
+				So(empty[2].Valid || empty[3].Valid || empty[4].Valid, ShouldBeFalse)
+				So(empty[5].Valid, ShouldBeTrue)
+				So(blanks[0].Valid && blanks[1].Valid, ShouldBeTrue)
+				So(blanks[2].Valid || blanks[3].Valid || blanks[4].Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
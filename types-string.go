@@ -2,10 +2,15 @@ package sqljson
 
 import (
 	"database/sql"
-	"encoding/json"
+	"database/sql/driver"
+	"encoding/xml"
 	"reflect"
 )
 
+// NormalizeString, when set, is applied to every string before it is
+// written to the database, e.g. strings.TrimSpace.
+var NormalizeString func(string) string
+
 // NullString //
 type NullString struct {
 	sql.NullString
@@ -14,43 +19,156 @@ type NullString struct {
 // NullStringValidateValuer //
 func NullStringValidateValuer(field reflect.Value) interface{} {
 	if nullString, ok := field.Interface().(NullString); ok {
-		if nullString.Valid {
-			return nullString.String
-		}
+		return nullString.ValidateValue()
 	}
 	return nil
 }
 
+func (ns NullString) null() Null[string] {
+	return newNull(ns.String, ns.Valid)
+}
+
+func nullStringOf(n Null[string]) NullString {
+	return NullString{NullString: sql.NullString{String: n.V, Valid: n.Valid}}
+}
+
+// NewNullString //
+func NewNullString(value string) NullString {
+	return nullStringOf(NewNull(value))
+}
+
+// NullStringFrom //
+func NullStringFrom(value *string) NullString {
+	return nullStringOf(NullFrom(value))
+}
+
+// NullStringFromZero //
+func NullStringFromZero(value string) NullString {
+	return nullStringOf(NullFromZero(value))
+}
+
+// ValidateValue //
+func (ns NullString) ValidateValue() interface{} {
+	return ns.null().ValidateValue()
+}
+
 // StringPtrOrNil //
 func (ns NullString) StringPtrOrNil() *string {
-	if ns.Valid {
-		s := ns.String
-		return &s
+	return ns.null().PtrOrNil()
+}
+
+// ValueOrZero //
+func (ns NullString) ValueOrZero() string {
+	return ns.null().ValueOrZero()
+}
+
+// ValueOr //
+func (ns NullString) ValueOr(value string) string {
+	return ns.null().ValueOr(value)
+}
+
+// IsZero //
+func (ns NullString) IsZero() bool {
+	return !ns.Valid
+}
+
+// Scan accepts texts, and numbers, booleans and times formatted as
+// database/sql does.
+func (ns *NullString) Scan(value interface{}) error {
+	if value == nil {
+		ns.String, ns.Valid = "", false
+		return nil
 	}
+	v, err := scanString(value, "NullString")
+	if err != nil {
+		return err
+	}
+	ns.String, ns.Valid = v, true
 	return nil
 }
 
+// Value //
+func (ns NullString) Value() (driver.Value, error) {
+	return ns.null().Value()
+}
+
 // MarshalJSON //
 func (ns NullString) MarshalJSON() ([]byte, error) {
-	if ns.Valid {
-		return json.Marshal(ns.String)
-	}
-	return json.Marshal(nil)
+	return ns.null().MarshalJSON()
 }
 
 // UnmarshalJSON //
 func (ns *NullString) UnmarshalJSON(data []byte) error {
-	value := new(string)
-	err := json.Unmarshal(data, &value)
+	n := Null[string]{}
+	err := n.UnmarshalJSON(data)
 	if err != nil {
 		return err
 	}
-	if value != nil {
-		ns.String = *value
-		ns.Valid = true
-	} else {
+	ns.String = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXML //
+func (ns NullString) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return ns.null().MarshalXML(e, start)
+}
+
+// UnmarshalXML //
+func (ns *NullString) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	n := Null[string]{}
+	err := n.UnmarshalXML(d, start)
+	if err != nil {
+		return err
+	}
+	ns.String = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXMLAttr //
+func (ns NullString) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return ns.null().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullString) UnmarshalXMLAttr(attr xml.Attr) error {
+	n := Null[string]{}
+	err := n.UnmarshalXMLAttr(attr)
+	if err != nil {
+		return err
+	}
+	ns.String = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// text returns the text of a non-NULL value.
+func (ns NullString) text() string {
+	return ns.String
+}
+
+// setText sets ns to the non-NULL value given as text.
+func (ns *NullString) setText(text string) error {
+	ns.String = text
+	ns.Valid = true
+	return nil
+}
+
+// MarshalText //
+func (ns NullString) MarshalText() ([]byte, error) {
+	if !ns.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(ns.text()), nil
+}
+
+// UnmarshalText //
+func (ns *NullString) UnmarshalText(data []byte) error {
+	if string(data) == NullText {
 		ns.String = ""
 		ns.Valid = false
+		return nil
 	}
-	return nil
+	return ns.setText(string(data))
 }
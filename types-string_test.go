@@ -275,3 +275,117 @@ func TestStringPtrOrNil(t *testing.T) {
 		})
 	})
 }
+
+func TestStringConstructors(t *testing.T) {
+	Convey("Given a string value, a pointer to it, a nil pointer and the zero value", t, func() {
+		value := "dummy"
+		Convey("When I build sqljson.NullString values from them", func() {
+			fromValue := sqljson.NewNullString(value)
+			fromPtr := sqljson.NullStringFrom(&value)
+			fromNil := sqljson.NullStringFrom(nil)
+			fromZero := sqljson.NullStringFromZero("")
+			fromNonZero := sqljson.NullStringFromZero(value)
+			Convey("Then only the nil pointer and the zero value should be NULL", func() {
+				So(fromValue.Valid, ShouldBeTrue)
+				So(fromValue.String, ShouldEqual, value)
+				So(fromPtr.Valid, ShouldBeTrue)
+				So(fromPtr.String, ShouldEqual, value)
+				So(fromNil.Valid, ShouldBeFalse)
+				So(fromZero.Valid, ShouldBeFalse)
+				So(fromNonZero.Valid, ShouldBeTrue)
+			})
+			Convey("Then ValueOrZero and ValueOr should fall back only when NULL", func() {
+				So(fromValue.ValueOrZero(), ShouldEqual, value)
+				So(fromValue.ValueOr("default"), ShouldEqual, value)
+				So(fromNil.ValueOrZero(), ShouldEqual, "")
+				So(fromNil.ValueOr("default"), ShouldEqual, "default")
+			})
+		})
+	})
+}
+
+func TestStringMarshalText(t *testing.T) {
+	Convey("Given a non-null and a null sqljson.NullString value", t, func() {
+		valueIn := sqljson.NewNullString("dummy")
+		null := sqljson.NullString{}
+		Convey("When I marshal them as text", func() {
+			b, err := valueIn.MarshalText()
+			bNull, errNull := null.MarshalText()
+			Convey("Then I should get the value and sqljson.NullText", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "dummy")
+				So(errNull, ShouldBeNil)
+				So(string(bNull), ShouldEqual, sqljson.NullText)
+			})
+		})
+	})
+}
+
+func TestStringUnmarshalText(t *testing.T) {
+	Convey("Given a sqljson.NullString value pointer, and a text value", t, func() {
+		ns := &sqljson.NullString{}
+		Convey("When I Unmarshal it using UnmarshalText", func() {
+			err := ns.UnmarshalText([]byte("dummy"))
+			Convey("Then I should get a not-Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeTrue)
+				So(ns.String, ShouldEqual, "dummy")
+			})
+		})
+		Convey("When I Unmarshal sqljson.NullText using UnmarshalText", func() {
+			_ = ns.UnmarshalText([]byte("dummy"))
+			err := ns.UnmarshalText([]byte(sqljson.NullText))
+			Convey("Then I should get a Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestStringNullText(t *testing.T) {
+	Convey("Given a valid empty sqljson.NullString and a NULL one, with the default sqljson.NullText", t, func() {
+		empty, null := sqljson.NewNullString(""), sqljson.NullString{}
+		Convey("When I marshal them as text and unmarshal them back", func() {
+			bEmpty, errEmpty := empty.MarshalText()
+			bNull, errNull := null.MarshalText()
+			emptyBack, nullBack := &sqljson.NullString{}, sqljson.NewNullString("x")
+			errEmptyBack := emptyBack.UnmarshalText(bEmpty)
+			errNullBack := nullBack.UnmarshalText(bNull)
+			Convey("Then I should get the same values back", func() {
+				So(errEmpty, ShouldBeNil)
+				So(errNull, ShouldBeNil)
+				So(string(bNull), ShouldEqual, `\N`)
+				So(errEmptyBack, ShouldBeNil)
+				So(*emptyBack, ShouldResemble, empty)
+				So(errNullBack, ShouldBeNil)
+				So(nullBack, ShouldResemble, null)
+			})
+		})
+		Convey("When I unmarshal an empty text into a sqljson.NullInt64", func() {
+			ni := sqljson.NewNullInt64(1)
+			err := ni.UnmarshalText([]byte(""))
+			Convey("Then I should get NULL, as no int64 is empty text", func() {
+				So(err, ShouldBeNil)
+				So(ni.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given sqljson.NullText set to a marker", t, func() {
+		nullText := sqljson.NullText
+		sqljson.NullText = "null"
+		Reset(func() { sqljson.NullText = nullText })
+		Convey("When I unmarshal an empty text and the marker", func() {
+			empty, null := &sqljson.NullString{}, &sqljson.NullString{}
+			errEmpty := empty.UnmarshalText([]byte(""))
+			errNull := null.UnmarshalText([]byte("null"))
+			Convey("Then I should get an empty string and NULL", func() {
+				So(errEmpty, ShouldBeNil)
+				So(empty.Valid, ShouldBeTrue)
+				So(empty.String, ShouldEqual, "")
+				So(errNull, ShouldBeNil)
+				So(null.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -0,0 +1,14 @@
+package sqljson
+
+// NullText is the text that MarshalText produces for NULL, and that
+// UnmarshalText decodes as NULL. It defaults to \N, the NULL marker of
+// PostgreSQL COPY and MySQL LOAD DATA text files, so that an empty
+// NullString keeps its value.
+var NullText = `\N`
+
+// isNullText reports whether data is NULL for types whose values are never
+// empty text: NullText, or empty text, so an empty form or query-string
+// field is NULL.
+func isNullText(data []byte) bool {
+	return len(data) == 0 || string(data) == NullText
+}
@@ -0,0 +1,264 @@
+package sqljson
+
+import (
+	"bytes"
+	"cmp"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// TimeOfDayLayout is the layout of times of day in JSON, text, XML and the
+// database. Fractional seconds are written only when not zero.
+const TimeOfDayLayout = "15:04:05.999999999"
+
+// timeOfDayLayouts are the layouts accepted when parsing a time of day.
+var timeOfDayLayouts = []string{TimeOfDayLayout, "15:04"}
+
+// TimeOfDay is a time of day, without a date or a time zone, as stored in
+// SQL TIME columns.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// ParseTimeOfDay parses a time of day such as "15:04:05", "15:04:05.123456"
+// or "15:04".
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	for _, layout := range timeOfDayLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return TimeOfDayOf(t), nil
+		}
+	}
+	return TimeOfDay{}, fmt.Errorf("sqljson: invalid time of day %q", s)
+}
+
+// TimeOfDayOf returns the time of day of t in its own location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Nanosecond: t.Nanosecond()}
+}
+
+// String returns the time of day in TimeOfDayLayout.
+func (t TimeOfDay) String() string {
+	return time.Date(0, 1, 1, t.Hour, t.Minute, t.Second, t.Nanosecond, time.UTC).Format(TimeOfDayLayout)
+}
+
+// On returns the time of day on date d in loc.
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond, loc)
+}
+
+// Compare returns -1, 0 or +1 when t is before, the same as, or after other.
+func (t TimeOfDay) Compare(other TimeOfDay) int {
+	if c := cmp.Compare(t.Hour, other.Hour); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.Minute, other.Minute); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(t.Second, other.Second); c != 0 {
+		return c
+	}
+	return cmp.Compare(t.Nanosecond, other.Nanosecond)
+}
+
+// scanTimeOfDay converts the driver representations of a time of day: times,
+// and texts such as "15:04:05" or "0000-01-01 15:04:05".
+func scanTimeOfDay(value interface{}) (TimeOfDay, error) {
+	if t, ok := value.(time.Time); ok {
+		return TimeOfDayOf(t), nil
+	}
+	text, ok := scanText(value)
+	if !ok {
+		return TimeOfDay{}, fmt.Errorf("sqljson: cannot scan %T into NullTimeOfDay", value)
+	}
+	if len(text) > len(DateLayout) && (text[len(DateLayout)] == ' ' || text[len(DateLayout)] == 'T') {
+		text = text[len(DateLayout)+1:]
+	}
+	return ParseTimeOfDay(text)
+}
+
+// NullTimeOfDay is a nullable time of day. Keeping it apart from any date
+// and time zone avoids the hour shifting when a TIME goes through a
+// timestamp.
+type NullTimeOfDay struct {
+	TimeOfDay TimeOfDay
+	Valid     bool
+}
+
+// NewNullTimeOfDay //
+func NewNullTimeOfDay(value TimeOfDay) NullTimeOfDay {
+	return NullTimeOfDay{TimeOfDay: value, Valid: true}
+}
+
+// NullTimeOfDayFrom //
+func NullTimeOfDayFrom(value *TimeOfDay) NullTimeOfDay {
+	if value == nil {
+		return NullTimeOfDay{}
+	}
+	return NewNullTimeOfDay(*value)
+}
+
+// NullTimeOfDayFromZero returns NULL for midnight.
+func NullTimeOfDayFromZero(value TimeOfDay) NullTimeOfDay {
+	if value == (TimeOfDay{}) {
+		return NullTimeOfDay{}
+	}
+	return NewNullTimeOfDay(value)
+}
+
+// NullTimeOfDayValidateValuer //
+func NullTimeOfDayValidateValuer(field reflect.Value) interface{} {
+	if nullTimeOfDay, ok := field.Interface().(NullTimeOfDay); ok {
+		return nullTimeOfDay.ValidateValue()
+	}
+	return nil
+}
+
+// ValidateValue returns the time of day in TimeOfDayLayout, which sorts like
+// the time of day, for the time_min and time_max tags and for cross-field
+// comparisons.
+func (ns NullTimeOfDay) ValidateValue() interface{} {
+	if ns.Valid {
+		return ns.text()
+	}
+	return nil
+}
+
+// TimeOfDayPtrOrNil //
+func (ns NullTimeOfDay) TimeOfDayPtrOrNil() *TimeOfDay {
+	if ns.Valid {
+		t := ns.TimeOfDay
+		return &t
+	}
+	return nil
+}
+
+// ValueOrZero //
+func (ns NullTimeOfDay) ValueOrZero() TimeOfDay {
+	return ns.ValueOr(TimeOfDay{})
+}
+
+// ValueOr //
+func (ns NullTimeOfDay) ValueOr(value TimeOfDay) TimeOfDay {
+	if ns.Valid {
+		return ns.TimeOfDay
+	}
+	return value
+}
+
+// IsZero //
+func (ns NullTimeOfDay) IsZero() bool {
+	return !ns.Valid
+}
+
+// Scan //
+func (ns *NullTimeOfDay) Scan(value interface{}) error {
+	if value == nil {
+		ns.TimeOfDay, ns.Valid = TimeOfDay{}, false
+		return nil
+	}
+	timeOfDay, err := scanTimeOfDay(value)
+	if err != nil {
+		return err
+	}
+	ns.TimeOfDay, ns.Valid = timeOfDay, true
+	return nil
+}
+
+// Value writes the time of day as text in TimeOfDayLayout.
+func (ns NullTimeOfDay) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return ns.text(), nil
+}
+
+// text returns the text of a non-NULL value.
+func (ns NullTimeOfDay) text() string {
+	return ns.TimeOfDay.String()
+}
+
+// setText sets ns to the non-NULL value given as text.
+func (ns *NullTimeOfDay) setText(text string) error {
+	value, err := ParseTimeOfDay(strings.TrimSpace(text))
+	if err != nil {
+		return err
+	}
+	ns.TimeOfDay, ns.Valid = value, true
+	return nil
+}
+
+// MarshalJSON //
+func (ns NullTimeOfDay) MarshalJSON() ([]byte, error) {
+	if !ns.Valid {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(ns.text())
+}
+
+// UnmarshalJSON //
+func (ns *NullTimeOfDay) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		ns.TimeOfDay, ns.Valid = TimeOfDay{}, false
+		return nil
+	}
+	var text string
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return err
+	}
+	return ns.setText(text)
+}
+
+// MarshalText //
+func (ns NullTimeOfDay) MarshalText() ([]byte, error) {
+	if !ns.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(ns.text()), nil
+}
+
+// UnmarshalText //
+func (ns *NullTimeOfDay) UnmarshalText(data []byte) error {
+	if isNullText(data) {
+		ns.TimeOfDay, ns.Valid = TimeOfDay{}, false
+		return nil
+	}
+	return ns.setText(string(data))
+}
+
+// MarshalXML //
+func (ns NullTimeOfDay) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(e, start, ns.text(), ns.Valid)
+}
+
+// UnmarshalXML //
+func (ns *NullTimeOfDay) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	text, ok, err := unmarshalXMLText(d, start)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		ns.TimeOfDay, ns.Valid = TimeOfDay{}, false
+		return nil
+	}
+	return ns.setText(text)
+}
+
+// MarshalXMLAttr //
+func (ns NullTimeOfDay) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, ns.text(), ns.Valid)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullTimeOfDay) UnmarshalXMLAttr(attr xml.Attr) error {
+	return ns.setText(attr.Value)
+}
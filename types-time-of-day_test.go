@@ -0,0 +1,128 @@
+package sqljson_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rhaseven7h/sqljson"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	Convey("Given times of day with and without seconds and fractions", t, func() {
+		cases := map[string]sqljson.TimeOfDay{
+			"09:30":           {Hour: 9, Minute: 30},
+			"09:30:15":        {Hour: 9, Minute: 30, Second: 15},
+			"23:59:59.123456": {Hour: 23, Minute: 59, Second: 59, Nanosecond: 123456000},
+		}
+		Convey("When I parse them", func() {
+			Convey("Then I should get their times of day", func() {
+				for text, want := range cases {
+					got, err := sqljson.ParseTimeOfDay(text)
+					So(err, ShouldBeNil)
+					So(got, ShouldResemble, want)
+				}
+			})
+		})
+	})
+	Convey("Given invalid times of day", t, func() {
+		Convey("When I parse them", func() {
+			Convey("Then I should get errors", func() {
+				for _, text := range []string{"", "24:00:00", "9:30 PM", "2024-05-01"} {
+					_, err := sqljson.ParseTimeOfDay(text)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
+
+func TestTimeOfDayJSON(t *testing.T) {
+	type jsonStruct struct {
+		Opens  sqljson.NullTimeOfDay `json:"opens"`
+		Closes sqljson.NullTimeOfDay `json:"closes"`
+		Break  sqljson.NullTimeOfDay `json:"break"`
+	}
+	Convey("Given JSON with times of day and a null", t, func() {
+		data := []byte(`{"opens":"09:00:00","closes":"17:30:00.5","break":null}`)
+		Convey("When I unmarshal it", func() {
+			var v jsonStruct
+			err := json.Unmarshal(data, &v)
+			Convey("Then I should get the times of day and NULL", func() {
+				So(err, ShouldBeNil)
+				So(v.Opens.TimeOfDay, ShouldResemble, sqljson.TimeOfDay{Hour: 9})
+				So(v.Closes.TimeOfDay, ShouldResemble, sqljson.TimeOfDay{Hour: 17, Minute: 30, Nanosecond: 500000000})
+				So(v.Break.Valid, ShouldBeFalse)
+			})
+			Convey("And marshaling it back should give the same JSON", func() {
+				out, errMarshal := json.Marshal(v)
+				So(errMarshal, ShouldBeNil)
+				So(string(out), ShouldEqual, string(data))
+			})
+		})
+	})
+}
+
+func TestTimeOfDaySQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	Convey("Given a sql mock returning times of day as times, bytes, strings and NULL", t, func() {
+		mock.
+			ExpectQuery(`SELECT a, b, c, d FROM shops`).
+			WillReturnRows(sqlmock.NewRows([]string{"a", "b", "c", "d"}).
+				AddRow(time.Date(0, 1, 1, 9, 30, 0, 0, time.UTC), []byte("09:30:00"), "0000-01-01 09:30:00", nil))
+		Convey("When I query a row and scan it", func() {
+			a, b, c, d := sqljson.NullTimeOfDay{}, sqljson.NullTimeOfDay{}, sqljson.NullTimeOfDay{}, sqljson.NullTimeOfDay{}
+			dbErr := db.QueryRow(`SELECT a, b, c, d FROM shops`).Scan(&a, &b, &c, &d)
+			Convey("Then every representation should give the same time of day", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+				for _, timeOfDay := range []sqljson.NullTimeOfDay{a, b, c} {
+					So(timeOfDay.Valid, ShouldBeTrue)
+					So(timeOfDay.TimeOfDay.String(), ShouldEqual, "09:30:00")
+				}
+				So(d.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a valid sqljson.NullTimeOfDay", t, func() {
+		Convey("When I get its value", func() {
+			value, err := sqljson.NewNullTimeOfDay(sqljson.TimeOfDay{Hour: 17, Minute: 5, Nanosecond: 250000}).Value()
+			Convey("Then I should get the time of day as text", func() {
+				So(err, ShouldBeNil)
+				So(value, ShouldEqual, "17:05:00.00025")
+			})
+		})
+	})
+}
+
+func TestTimeOfDayValidator(t *testing.T) {
+	type validatorStruct struct {
+		Opens  sqljson.NullTimeOfDay `json:"opens" validate:"required,time_min=06:00,time_max=12:00"`
+		Closes sqljson.NullTimeOfDay `json:"closes" validate:"omitempty,time_max=23:59:59"`
+	}
+	Convey("Given a validator from NewValidator", t, func() {
+		validate := sqljson.NewValidator()
+		Convey("When I validate times of day in and out of range", func() {
+			errIn := validate.Struct(&validatorStruct{
+				Opens:  sqljson.NewNullTimeOfDay(sqljson.TimeOfDay{Hour: 6}),
+				Closes: sqljson.NewNullTimeOfDay(sqljson.TimeOfDay{Hour: 23, Minute: 59, Second: 59}),
+			})
+			errEarly := validate.Struct(&validatorStruct{Opens: sqljson.NewNullTimeOfDay(sqljson.TimeOfDay{Hour: 5, Minute: 59})})
+			errLate := validate.Struct(&validatorStruct{Opens: sqljson.NewNullTimeOfDay(sqljson.TimeOfDay{Hour: 12, Second: 1})})
+			Convey("Then I should get appropriate results", func() {
+				So(errIn, ShouldBeNil)
+				So(validationTags(errEarly), ShouldResemble, map[string]string{"opens": "time_min"})
+				So(validationTags(errLate), ShouldResemble, map[string]string{"opens": "time_max"})
+			})
+		})
+	})
+}
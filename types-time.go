@@ -0,0 +1,192 @@
+package sqljson
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"time"
+)
+
+// TimeLayout is the layout used by NullTime to marshal and unmarshal JSON
+// values. It defaults to RFC 3339 (with optional fractional seconds).
+var TimeLayout = time.RFC3339Nano
+
+// NormalizeTime, when set, is applied to every time before it is written to
+// the database, e.g. to convert it to UTC or truncate it to the precision of
+// the column.
+var NormalizeTime func(time.Time) time.Time
+
+// NullTime //
+type NullTime struct {
+	sql.NullTime
+}
+
+// NullTimeValidateValuer //
+func NullTimeValidateValuer(field reflect.Value) interface{} {
+	if nullTime, ok := field.Interface().(NullTime); ok {
+		return nullTime.ValidateValue()
+	}
+	return nil
+}
+
+func (ns NullTime) null() Null[time.Time] {
+	return newNull(ns.Time, ns.Valid)
+}
+
+func nullTimeOf(n Null[time.Time]) NullTime {
+	return NullTime{NullTime: sql.NullTime{Time: n.V, Valid: n.Valid}}
+}
+
+// NewNullTime //
+func NewNullTime(value time.Time) NullTime {
+	return nullTimeOf(NewNull(value))
+}
+
+// NullTimeFrom //
+func NullTimeFrom(value *time.Time) NullTime {
+	return nullTimeOf(NullFrom(value))
+}
+
+// NullTimeFromZero //
+func NullTimeFromZero(value time.Time) NullTime {
+	return nullTimeOf(NullFromZero(value))
+}
+
+// ValidateValue //
+func (ns NullTime) ValidateValue() interface{} {
+	return ns.null().ValidateValue()
+}
+
+// TimePtrOrNil //
+func (ns NullTime) TimePtrOrNil() *time.Time {
+	return ns.null().PtrOrNil()
+}
+
+// ValueOrZero //
+func (ns NullTime) ValueOrZero() time.Time {
+	return ns.null().ValueOrZero()
+}
+
+// ValueOr //
+func (ns NullTime) ValueOr(value time.Time) time.Time {
+	return ns.null().ValueOr(value)
+}
+
+// IsZero //
+func (ns NullTime) IsZero() bool {
+	return !ns.Valid
+}
+
+// Scan accepts times, texts in TimeLayout or in the formats of the common
+// drivers, and integers as Unix seconds.
+func (ns *NullTime) Scan(value interface{}) error {
+	if value == nil {
+		ns.Time, ns.Valid = time.Time{}, false
+		return nil
+	}
+	v, err := scanTime(value, "NullTime")
+	if err != nil {
+		return err
+	}
+	ns.Time, ns.Valid = v, true
+	return nil
+}
+
+// Value //
+func (ns NullTime) Value() (driver.Value, error) {
+	return ns.null().Value()
+}
+
+// MarshalJSON //
+func (ns NullTime) MarshalJSON() ([]byte, error) {
+	if ns.Valid {
+		return json.Marshal(ns.Time.Format(TimeLayout))
+	}
+	return json.Marshal(nil)
+}
+
+// UnmarshalJSON //
+func (ns *NullTime) UnmarshalJSON(data []byte) error {
+	value := new(string)
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if value != nil {
+		t, err := time.Parse(TimeLayout, *value)
+		if err != nil {
+			return err
+		}
+		ns.Time = t
+		ns.Valid = true
+	} else {
+		ns.Time = time.Time{}
+		ns.Valid = false
+	}
+	return nil
+}
+
+// MarshalXML marshals the time using TimeLayout.
+func (ns NullTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(e, start, ns.text(), ns.Valid)
+}
+
+// UnmarshalXML //
+func (ns *NullTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	text, ok, err := unmarshalXMLText(d, start)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		ns.Time = time.Time{}
+		ns.Valid = false
+		return nil
+	}
+	return ns.setText(text)
+}
+
+// MarshalXMLAttr //
+func (ns NullTime) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, ns.text(), ns.Valid)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullTime) UnmarshalXMLAttr(attr xml.Attr) error {
+	return ns.setText(attr.Value)
+}
+
+// text returns the text of a non-NULL value.
+func (ns NullTime) text() string {
+	return ns.Time.Format(TimeLayout)
+}
+
+// setText sets ns to the non-NULL value given as text.
+func (ns *NullTime) setText(text string) error {
+	value, err := time.Parse(TimeLayout, text)
+	if err != nil {
+		return err
+	}
+	ns.Time = value
+	ns.Valid = true
+	return nil
+}
+
+// MarshalText //
+func (ns NullTime) MarshalText() ([]byte, error) {
+	if !ns.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(ns.text()), nil
+}
+
+// UnmarshalText //
+func (ns *NullTime) UnmarshalText(data []byte) error {
+	if isNullText(data) {
+		ns.Time = time.Time{}
+		ns.Valid = false
+		return nil
+	}
+	return ns.setText(string(data))
+}
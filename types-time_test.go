@@ -0,0 +1,205 @@
+package sqljson_test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rhaseven7h/sqljson"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNullTimeValidateValuer(t *testing.T) {
+	Convey("Given a non-null sqljson.NullTime value", t, func() {
+		tm := time.Date(2017, time.May, 1, 10, 20, 30, 0, time.UTC)
+		ov := sqljson.NullTime{
+			NullTime: sql.NullTime{
+				Time:  tm,
+				Valid: true,
+			},
+		}
+		ivIn := reflect.ValueOf(ov)
+		Convey("When evaluated", func() {
+			ivOut := sqljson.NullTimeValidateValuer(ivIn)
+			Convey("Then we should get the value", func() {
+				nv, ok := ivOut.(time.Time)
+				So(ok, ShouldBeTrue)
+				So(nv.Equal(tm), ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a null sqljson.NullTime value", t, func() {
+		ov := sqljson.NullTime{}
+		ivIn := reflect.ValueOf(ov)
+		Convey("When evaluated", func() {
+			ivOut := sqljson.NullTimeValidateValuer(ivIn)
+			Convey("Then we should get nil", func() {
+				So(ivOut, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestTimeMarshalJSON(t *testing.T) {
+	Convey("Given a non-null sqljson.NullTime value", t, func() {
+		valueIn := sqljson.NullTime{
+			NullTime: sql.NullTime{
+				Time:  time.Date(2017, time.May, 1, 10, 20, 30, 500, time.UTC),
+				Valid: true,
+			},
+		}
+		Convey("When I marshal it", func() {
+			b, err := valueIn.MarshalJSON()
+			Convey("Then I should get an RFC 3339 string", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `"2017-05-01T10:20:30.0000005Z"`)
+			})
+		})
+	})
+	Convey("Given a null sqljson.NullTime value", t, func() {
+		valueIn := sqljson.NullTime{}
+		Convey("When I marshal it", func() {
+			b, err := valueIn.MarshalJSON()
+			Convey("Then I should get null", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `null`)
+			})
+		})
+	})
+}
+
+func TestTimeUnmarshalJSON(t *testing.T) {
+	Convey("Given a sqljson.NullTime value pointer, and a null JSON value", t, func() {
+		ns := &sqljson.NullTime{}
+		bJSON := []byte(`null`)
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := ns.UnmarshalJSON(bJSON)
+			Convey("Then I should get a Null NullTime", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a sqljson.NullTime value pointer, and an RFC 3339 JSON value", t, func() {
+		ns := &sqljson.NullTime{}
+		bJSON := []byte(`"2017-05-01T10:20:30-05:00"`)
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := ns.UnmarshalJSON(bJSON)
+			Convey("Then I should get a not-Null NullTime", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeTrue)
+				So(ns.Time.Equal(time.Date(2017, time.May, 1, 15, 20, 30, 0, time.UTC)), ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a sqljson.NullTime value pointer, and a malformed time JSON value", t, func() {
+		ns := &sqljson.NullTime{}
+		bJSON := []byte(`"01/05/2017"`)
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := ns.UnmarshalJSON(bJSON)
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "cannot parse")
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a sqljson.NullTime value pointer, and a number JSON value", t, func() {
+		ns := &sqljson.NullTime{}
+		bJSON := []byte(`1493634030`)
+		Convey("When I Unmarshal it using UnmarshalJSON", func() {
+			err := ns.UnmarshalJSON(bJSON)
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "cannot unmarshal number")
+			})
+		})
+	})
+}
+
+func TestTimePtrOrNil(t *testing.T) {
+	Convey("Given a valid non-Null NullTime value", t, func() {
+		tm := time.Date(2017, time.May, 1, 10, 20, 30, 0, time.UTC)
+		value := sqljson.NullTime{
+			NullTime: sql.NullTime{
+				Time:  tm,
+				Valid: true,
+			},
+		}
+		Convey("When we get the TimePtrOrNil", func() {
+			res := value.TimePtrOrNil()
+			Convey("Then we get the time value", func() {
+				So(res, ShouldNotBeNil)
+				So(res.Equal(tm), ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a valid null NullTime value", t, func() {
+		value := sqljson.NullTime{}
+		Convey("When we get the TimePtrOrNil", func() {
+			res := value.TimePtrOrNil()
+			Convey("Then we get nil", func() {
+				So(res, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestTimeConstructors(t *testing.T) {
+	Convey("Given a time value, a pointer to it, a nil pointer and zero times", t, func() {
+		value := time.Date(2017, time.May, 1, 10, 20, 30, 0, time.UTC)
+		Convey("When I build sqljson.NullTime values from them", func() {
+			fromValue := sqljson.NewNullTime(value)
+			fromPtr := sqljson.NullTimeFrom(&value)
+			fromNil := sqljson.NullTimeFrom(nil)
+			fromZero := sqljson.NullTimeFromZero(time.Time{})
+			fromLocalZero := sqljson.NullTimeFromZero(time.Time{}.In(time.FixedZone("UTC-5", -5*60*60)))
+			Convey("Then only the nil pointer and the zero times should be NULL", func() {
+				So(fromValue.Valid, ShouldBeTrue)
+				So(fromValue.Time.Equal(value), ShouldBeTrue)
+				So(fromPtr.Valid, ShouldBeTrue)
+				So(fromNil.Valid, ShouldBeFalse)
+				So(fromZero.Valid, ShouldBeFalse)
+				So(fromLocalZero.Valid, ShouldBeFalse)
+			})
+			Convey("Then ValueOrZero and ValueOr should fall back only when NULL", func() {
+				So(fromValue.ValueOrZero().Equal(value), ShouldBeTrue)
+				So(fromNil.ValueOrZero().IsZero(), ShouldBeTrue)
+				So(fromNil.ValueOr(value).Equal(value), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestTimeText(t *testing.T) {
+	Convey("Given a non-null sqljson.NullTime value", t, func() {
+		valueIn := sqljson.NewNullTime(time.Date(2017, time.May, 1, 10, 20, 30, 0, time.UTC))
+		Convey("When I marshal it as text and unmarshal it back", func() {
+			b, err := valueIn.MarshalText()
+			valueOut := sqljson.NullTime{}
+			errOut := valueOut.UnmarshalText(b)
+			Convey("Then I should get an RFC 3339 text and the same time", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "2017-05-01T10:20:30Z")
+				So(errOut, ShouldBeNil)
+				So(valueOut.Valid, ShouldBeTrue)
+				So(valueOut.Time.Equal(valueIn.Time), ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a sqljson.NullTime value pointer", t, func() {
+		ns := &sqljson.NullTime{}
+		Convey("When I Unmarshal an empty and a malformed text", func() {
+			errEmpty := ns.UnmarshalText([]byte(""))
+			validEmpty := ns.Valid
+			errBad := ns.UnmarshalText([]byte("01/05/2017"))
+			Convey("Then I should get NULL and an error", func() {
+				So(errEmpty, ShouldBeNil)
+				So(validEmpty, ShouldBeFalse)
+				So(errBad, ShouldNotBeNil)
+				So(errBad.Error(), ShouldContainSubstring, "cannot parse")
+			})
+		})
+	})
+}
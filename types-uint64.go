@@ -0,0 +1,149 @@
+package sqljson
+
+import (
+	"database/sql/driver"
+	"encoding/xml"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// NullUint64 is a nullable unsigned 64-bit integer, as stored in unsigned
+// BIGINT columns. Scan rejects negative and out of range values.
+type NullUint64 struct {
+	Uint64 uint64
+	Valid  bool
+}
+
+// NullUint64ValidateValuer //
+func NullUint64ValidateValuer(field reflect.Value) interface{} {
+	if nullUint64, ok := field.Interface().(NullUint64); ok {
+		return nullUint64.ValidateValue()
+	}
+	return nil
+}
+
+func (ns NullUint64) null() Null[uint64] {
+	return newNull(ns.Uint64, ns.Valid)
+}
+
+func nullUint64Of(n Null[uint64]) NullUint64 {
+	return NullUint64{Uint64: n.V, Valid: n.Valid}
+}
+
+// NewNullUint64 //
+func NewNullUint64(value uint64) NullUint64 {
+	return nullUint64Of(NewNull(value))
+}
+
+// NullUint64From //
+func NullUint64From(value *uint64) NullUint64 {
+	return nullUint64Of(NullFrom(value))
+}
+
+// NullUint64FromZero //
+func NullUint64FromZero(value uint64) NullUint64 {
+	return nullUint64Of(NullFromZero(value))
+}
+
+// ValidateValue //
+func (ns NullUint64) ValidateValue() interface{} {
+	return ns.null().ValidateValue()
+}
+
+// Uint64PtrOrNil //
+func (ns NullUint64) Uint64PtrOrNil() *uint64 {
+	return ns.null().PtrOrNil()
+}
+
+// ValueOrZero //
+func (ns NullUint64) ValueOrZero() uint64 {
+	return ns.null().ValueOrZero()
+}
+
+// ValueOr //
+func (ns NullUint64) ValueOr(value uint64) uint64 {
+	return ns.null().ValueOr(value)
+}
+
+// IsZero //
+func (ns NullUint64) IsZero() bool {
+	return !ns.Valid
+}
+
+// Scan //
+func (ns *NullUint64) Scan(value interface{}) error {
+	if value == nil {
+		ns.Uint64, ns.Valid = 0, false
+		return nil
+	}
+	u, err := scanUint(value, "NullUint64")
+	if err != nil {
+		return err
+	}
+	ns.Uint64, ns.Valid = u, true
+	return nil
+}
+
+// Value returns values above math.MaxInt64, which are not valid driver
+// values, as decimal text.
+func (ns NullUint64) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	if ns.Uint64 > math.MaxInt64 {
+		return strconv.FormatUint(ns.Uint64, 10), nil
+	}
+	return int64(ns.Uint64), nil
+}
+
+// MarshalJSON //
+func (ns NullUint64) MarshalJSON() ([]byte, error) {
+	return ns.null().MarshalJSON()
+}
+
+// UnmarshalJSON //
+func (ns *NullUint64) UnmarshalJSON(data []byte) error {
+	n := Null[uint64]{}
+	err := n.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	ns.Uint64 = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXML //
+func (ns NullUint64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return ns.null().MarshalXML(e, start)
+}
+
+// UnmarshalXML //
+func (ns *NullUint64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	n := Null[uint64]{}
+	err := n.UnmarshalXML(d, start)
+	if err != nil {
+		return err
+	}
+	ns.Uint64 = n.V
+	ns.Valid = n.Valid
+	return nil
+}
+
+// MarshalXMLAttr //
+func (ns NullUint64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return ns.null().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullUint64) UnmarshalXMLAttr(attr xml.Attr) error {
+	n := Null[uint64]{}
+	err := n.UnmarshalXMLAttr(attr)
+	if err != nil {
+		return err
+	}
+	ns.Uint64 = n.V
+	ns.Valid = n.Valid
+	return nil
+}
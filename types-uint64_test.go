@@ -0,0 +1,188 @@
+package sqljson_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNullUint64ValidateValuer(t *testing.T) {
+	Convey("Given a non-null sqljson.NullUint64 value", t, func() {
+		ov := sqljson.NullUint64{Uint64: 18446744073709551615, Valid: true}
+		Convey("When evaluated", func() {
+			ivOut := sqljson.NullUint64ValidateValuer(reflect.ValueOf(ov))
+			Convey("Then we should get the value", func() {
+				nv, ok := ivOut.(uint64)
+				So(ok, ShouldBeTrue)
+				So(nv, ShouldEqual, uint64(18446744073709551615))
+			})
+		})
+	})
+	Convey("Given a null sqljson.NullUint64 value", t, func() {
+		ov := sqljson.NullUint64{}
+		Convey("When evaluated", func() {
+			ivOut := sqljson.NullUint64ValidateValuer(reflect.ValueOf(ov))
+			Convey("Then we should get nil", func() {
+				So(ivOut, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestUint64PtrOrNil(t *testing.T) {
+	Convey("Given a valid non-Null NullUint64 value", t, func() {
+		value := sqljson.NullUint64{Uint64: 7, Valid: true}
+		Convey("When we get the Uint64PtrOrNil", func() {
+			res := value.Uint64PtrOrNil()
+			Convey("Then we get the value", func() {
+				So(res, ShouldNotBeNil)
+				So(*res, ShouldEqual, 7)
+			})
+		})
+	})
+	Convey("Given a valid null NullUint64 value", t, func() {
+		value := sqljson.NullUint64{}
+		Convey("When we get the Uint64PtrOrNil", func() {
+			res := value.Uint64PtrOrNil()
+			Convey("Then we get nil", func() {
+				So(res, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestUint64JSON(t *testing.T) {
+	Convey("Given a non-null sqljson.NullUint64 value", t, func() {
+		valueIn := sqljson.NullUint64{Uint64: 18446744073709551615, Valid: true}
+		Convey("When I marshal it", func() {
+			b, err := valueIn.MarshalJSON()
+			Convey("Then I should get the number", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `18446744073709551615`)
+			})
+		})
+	})
+	Convey("Given a sqljson.NullUint64 value pointer", t, func() {
+		ns := &sqljson.NullUint64{}
+		Convey("When I Unmarshal the largest uint64", func() {
+			err := ns.UnmarshalJSON([]byte(`18446744073709551615`))
+			Convey("Then I should get the value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeTrue)
+				So(ns.Uint64, ShouldEqual, uint64(18446744073709551615))
+			})
+		})
+		Convey("When I Unmarshal a value which overflows", func() {
+			err := ns.UnmarshalJSON([]byte(`18446744073709551616`))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "cannot unmarshal number 18446744073709551616 into Go value of type uint64")
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+		Convey("When I Unmarshal a negative value", func() {
+			err := ns.UnmarshalJSON([]byte(`-1`))
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "cannot unmarshal number -1 into Go value of type uint64")
+			})
+		})
+		Convey("When I Unmarshal null", func() {
+			err := ns.UnmarshalJSON([]byte(`null`))
+			Convey("Then I should get a Null value", func() {
+				So(err, ShouldBeNil)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestUint64SQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	Convey("Given a sql mock returning unsigned values in several driver types", t, func() {
+		mock.
+			ExpectQuery(`SELECT a, b, c, d FROM counters`).
+			WillReturnRows(sqlmock.NewRows([]string{"a", "b", "c", "d"}).
+				AddRow(int64(10), []byte("18446744073709551615"), "42", nil))
+		Convey("When I query a row and scan it", func() {
+			a, b, c, d := sqljson.NullUint64{}, sqljson.NullUint64{}, sqljson.NullUint64{}, sqljson.NullUint64{}
+			dbErr := db.QueryRow(`SELECT a, b, c, d FROM counters`).Scan(&a, &b, &c, &d)
+			Convey("Then I should get the values", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+				So(a.Uint64, ShouldEqual, 10)
+				So(b.Uint64, ShouldEqual, uint64(18446744073709551615))
+				So(c.Uint64, ShouldEqual, 42)
+				So(d.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a sqljson.NullUint64 value pointer", t, func() {
+		ns := &sqljson.NullUint64{}
+		Convey("When I scan out of range values", func() {
+			errNegative := ns.Scan(int64(-1))
+			errOverflow := ns.Scan([]byte("18446744073709551616"))
+			errFraction := ns.Scan(1.5)
+			Convey("Then I should get descriptive errors", func() {
+				So(errNegative, ShouldNotBeNil)
+				So(errNegative.Error(), ShouldEqual, "sqljson: cannot scan -1 into NullUint64: value out of range")
+				So(errOverflow, ShouldNotBeNil)
+				So(errOverflow.Error(), ShouldEqual, `sqljson: cannot scan "18446744073709551616" into NullUint64: value out of range`)
+				So(errFraction, ShouldNotBeNil)
+				So(ns.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given small and large sqljson.NullUint64 values", t, func() {
+		a := sqljson.NullUint64{Uint64: 10, Valid: true}
+		b := sqljson.NullUint64{Uint64: 18446744073709551615, Valid: true}
+		Convey("When I write them to the database", func() {
+			mock.
+				ExpectExec(`UPDATE counters SET a = \?, b = \?, c = \?`).
+				WithArgs(int64(10), "18446744073709551615", nil).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			_, dbErr := db.Exec(`UPDATE counters SET a = ?, b = ?, c = ?`, a, b, sqljson.NullUint64{})
+			Convey("Then they should be written as valid driver values", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestUint64Constructors(t *testing.T) {
+	Convey("Given a uint64 value, a pointer to it, a nil pointer and the zero value", t, func() {
+		value := uint64(18446744073709551615)
+		Convey("When I build sqljson.NullUint64 values from them", func() {
+			fromValue := sqljson.NewNullUint64(value)
+			fromPtr := sqljson.NullUint64From(&value)
+			fromNil := sqljson.NullUint64From(nil)
+			fromZero := sqljson.NullUint64FromZero(uint64(0))
+			fromNonZero := sqljson.NullUint64FromZero(value)
+			Convey("Then only the nil pointer and the zero value should be NULL", func() {
+				So(fromValue.Valid, ShouldBeTrue)
+				So(fromValue.Uint64, ShouldEqual, value)
+				So(fromPtr.Valid, ShouldBeTrue)
+				So(fromPtr.Uint64, ShouldEqual, value)
+				So(fromNil.Valid, ShouldBeFalse)
+				So(fromZero.Valid, ShouldBeFalse)
+				So(fromNonZero.Valid, ShouldBeTrue)
+			})
+			Convey("Then ValueOrZero and ValueOr should fall back only when NULL", func() {
+				So(fromValue.ValueOrZero(), ShouldEqual, value)
+				So(fromValue.ValueOr(uint64(1)), ShouldEqual, value)
+				So(fromNil.ValueOrZero(), ShouldEqual, uint64(0))
+				So(fromNil.ValueOr(uint64(1)), ShouldEqual, uint64(1))
+			})
+		})
+	})
+}
@@ -0,0 +1,254 @@
+package sqljson
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// UUID is a universally unique identifier, as defined by RFC 4122.
+type UUID [16]byte
+
+// ParseUUID parses a UUID in canonical form, such as
+// "6ba7b810-9dad-11d1-80b4-00c04fd430c8", in braces, as in
+// "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}", or as a URN, as in
+// "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8". Hex digits may be in
+// either case.
+func ParseUUID(s string) (UUID, error) {
+	text := s
+	switch {
+	case len(text) == 38 && text[0] == '{' && text[37] == '}':
+		text = text[1:37]
+	case len(text) == 45 && strings.EqualFold(text[:9], "urn:uuid:"):
+		text = text[9:]
+	}
+	var u UUID
+	if len(text) != 36 || text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+		return u, fmt.Errorf("sqljson: invalid UUID %q", s)
+	}
+	digits := text[:8] + text[9:13] + text[14:18] + text[19:23] + text[24:]
+	_, err := hex.Decode(u[:], []byte(digits))
+	if err != nil {
+		return UUID{}, fmt.Errorf("sqljson: invalid UUID %q", s)
+	}
+	return u, nil
+}
+
+// String returns the UUID in canonical form, in lower case.
+func (u UUID) String() string {
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf)
+}
+
+// UUIDFormat is a column format for UUIDs.
+type UUIDFormat int
+
+const (
+	// UUIDText stores UUIDs in canonical form, as in CHAR(36) columns and
+	// PostgreSQL's uuid type.
+	UUIDText UUIDFormat = iota
+	// UUIDBinary stores UUIDs as their 16 bytes, as in BINARY(16) columns.
+	UUIDBinary
+)
+
+// UUIDStorage is the format NullUUID writes to the database. NullUUID scans
+// both formats whatever its value.
+var UUIDStorage = UUIDText
+
+// NullUUID is a nullable UUID.
+type NullUUID struct {
+	UUID  UUID
+	Valid bool
+}
+
+// NewNullUUID //
+func NewNullUUID(value UUID) NullUUID {
+	return NullUUID{UUID: value, Valid: true}
+}
+
+// NullUUIDFrom //
+func NullUUIDFrom(value *UUID) NullUUID {
+	if value == nil {
+		return NullUUID{}
+	}
+	return NewNullUUID(*value)
+}
+
+// NullUUIDFromZero returns NULL for the nil UUID, whose bytes are all zero.
+func NullUUIDFromZero(value UUID) NullUUID {
+	if value == (UUID{}) {
+		return NullUUID{}
+	}
+	return NewNullUUID(value)
+}
+
+// NullUUIDValidateValuer //
+func NullUUIDValidateValuer(field reflect.Value) interface{} {
+	if nullUUID, ok := field.Interface().(NullUUID); ok {
+		return nullUUID.ValidateValue()
+	}
+	return nil
+}
+
+// ValidateValue returns the UUID in canonical form, so that string
+// validations such as len or uuid4 can be applied to it.
+func (ns NullUUID) ValidateValue() interface{} {
+	if ns.Valid {
+		return ns.UUID.String()
+	}
+	return nil
+}
+
+// UUIDPtrOrNil //
+func (ns NullUUID) UUIDPtrOrNil() *UUID {
+	if ns.Valid {
+		u := ns.UUID
+		return &u
+	}
+	return nil
+}
+
+// ValueOrZero //
+func (ns NullUUID) ValueOrZero() UUID {
+	return ns.ValueOr(UUID{})
+}
+
+// ValueOr //
+func (ns NullUUID) ValueOr(value UUID) UUID {
+	if ns.Valid {
+		return ns.UUID
+	}
+	return value
+}
+
+// IsZero //
+func (ns NullUUID) IsZero() bool {
+	return !ns.Valid
+}
+
+// Scan accepts UUIDs as text in any of the forms of ParseUUID, and as 16
+// bytes.
+func (ns *NullUUID) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		ns.UUID, ns.Valid = UUID{}, false
+		return nil
+	case []byte:
+		if len(v) == len(ns.UUID) {
+			copy(ns.UUID[:], v)
+			ns.Valid = true
+			return nil
+		}
+		return ns.setText(string(v))
+	case string:
+		return ns.setText(v)
+	}
+	return fmt.Errorf("sqljson: cannot scan %T into NullUUID", value)
+}
+
+// Value writes the UUID in the format of UUIDStorage.
+func (ns NullUUID) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	if UUIDStorage == UUIDBinary {
+		return ns.UUID[:], nil
+	}
+	return ns.UUID.String(), nil
+}
+
+// text returns the text of a non-NULL value.
+func (ns NullUUID) text() string {
+	return ns.UUID.String()
+}
+
+// setText sets ns to the non-NULL value given as text.
+func (ns *NullUUID) setText(text string) error {
+	value, err := ParseUUID(strings.TrimSpace(text))
+	if err != nil {
+		return err
+	}
+	ns.UUID, ns.Valid = value, true
+	return nil
+}
+
+// MarshalJSON //
+func (ns NullUUID) MarshalJSON() ([]byte, error) {
+	if !ns.Valid {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(ns.text())
+}
+
+// UnmarshalJSON //
+func (ns *NullUUID) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		ns.UUID, ns.Valid = UUID{}, false
+		return nil
+	}
+	var text string
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return err
+	}
+	return ns.setText(text)
+}
+
+// MarshalText //
+func (ns NullUUID) MarshalText() ([]byte, error) {
+	if !ns.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(ns.text()), nil
+}
+
+// UnmarshalText //
+func (ns *NullUUID) UnmarshalText(data []byte) error {
+	if isNullText(data) {
+		ns.UUID, ns.Valid = UUID{}, false
+		return nil
+	}
+	return ns.setText(string(data))
+}
+
+// MarshalXML //
+func (ns NullUUID) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLText(e, start, ns.text(), ns.Valid)
+}
+
+// UnmarshalXML //
+func (ns *NullUUID) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	text, ok, err := unmarshalXMLText(d, start)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		ns.UUID, ns.Valid = UUID{}, false
+		return nil
+	}
+	return ns.setText(text)
+}
+
+// MarshalXMLAttr //
+func (ns NullUUID) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttrText(name, ns.text(), ns.Valid)
+}
+
+// UnmarshalXMLAttr //
+func (ns *NullUUID) UnmarshalXMLAttr(attr xml.Attr) error {
+	return ns.setText(attr.Value)
+}
@@ -0,0 +1,148 @@
+package sqljson_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rhaseven7h/sqljson"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	validator "gopkg.in/go-playground/validator.v9"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const uuidText = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
+var uuidBytes = []byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+func TestParseUUID(t *testing.T) {
+	Convey("Given UUIDs in canonical, braced and URN forms", t, func() {
+		forms := []string{
+			uuidText,
+			"6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+			"{" + uuidText + "}",
+			"urn:uuid:" + uuidText,
+		}
+		Convey("When I parse them", func() {
+			Convey("Then I should get the same UUID", func() {
+				for _, form := range forms {
+					u, err := sqljson.ParseUUID(form)
+					So(err, ShouldBeNil)
+					So(u[:], ShouldResemble, uuidBytes)
+					So(u.String(), ShouldEqual, uuidText)
+				}
+			})
+		})
+	})
+	Convey("Given malformed UUIDs", t, func() {
+		forms := []string{"", "6ba7b8109dad11d180b400c04fd430c8", "{" + uuidText, "6ba7b810-9dad-11d1-80b4-00c04fd430cg"}
+		Convey("When I parse them", func() {
+			Convey("Then I should get errors", func() {
+				for _, form := range forms {
+					_, err := sqljson.ParseUUID(form)
+					So(err, ShouldNotBeNil)
+				}
+			})
+		})
+	})
+}
+
+func TestUUIDJSON(t *testing.T) {
+	type jsonStruct struct {
+		ID       sqljson.NullUUID `json:"id"`
+		ParentID sqljson.NullUUID `json:"parent_id"`
+	}
+	Convey("Given JSON with a braced UUID and a null", t, func() {
+		data := []byte(`{"id":"{` + uuidText + `}","parent_id":null}`)
+		Convey("When I unmarshal it", func() {
+			var v jsonStruct
+			err := json.Unmarshal(data, &v)
+			Convey("Then I should get a valid and a null UUID", func() {
+				So(err, ShouldBeNil)
+				So(v.ID.Valid, ShouldBeTrue)
+				So(v.ID.UUID.String(), ShouldEqual, uuidText)
+				So(v.ParentID.Valid, ShouldBeFalse)
+			})
+			Convey("And marshaling it back should write canonical UUIDs", func() {
+				out, errMarshal := json.Marshal(v)
+				So(errMarshal, ShouldBeNil)
+				So(string(out), ShouldEqual, `{"id":"`+uuidText+`","parent_id":null}`)
+			})
+		})
+	})
+	Convey("Given JSON with an invalid UUID", t, func() {
+		Convey("When I unmarshal it", func() {
+			var v jsonStruct
+			err := json.Unmarshal([]byte(`{"id":"not-a-uuid"}`), &v)
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestUUIDSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	Convey("Given a sql mock returning UUIDs as text, bytes and NULL", t, func() {
+		mock.
+			ExpectQuery(`SELECT a, b, c, d FROM suppliers`).
+			WillReturnRows(sqlmock.NewRows([]string{"a", "b", "c", "d"}).
+				AddRow(uuidText, []byte(uuidText), uuidBytes, nil))
+		Convey("When I query a row and scan it", func() {
+			a, b, c, d := sqljson.NullUUID{}, sqljson.NullUUID{}, sqljson.NullUUID{}, sqljson.NullUUID{}
+			dbErr := db.QueryRow(`SELECT a, b, c, d FROM suppliers`).Scan(&a, &b, &c, &d)
+			Convey("Then I should get the same UUID from every storage format", func() {
+				So(dbErr, ShouldBeNil)
+				So(mock.ExpectationsWereMet(), ShouldBeNil)
+				So(a.UUID.String(), ShouldEqual, uuidText)
+				So(b.UUID, ShouldResemble, a.UUID)
+				So(c.UUID, ShouldResemble, a.UUID)
+				So(d.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a valid sqljson.NullUUID", t, func() {
+		u, _ := sqljson.ParseUUID(uuidText)
+		ns := sqljson.NewNullUUID(u)
+		Convey("When I get its value with each storage format", func() {
+			text, errText := ns.Value()
+			sqljson.UUIDStorage = sqljson.UUIDBinary
+			binary, errBinary := ns.Value()
+			sqljson.UUIDStorage = sqljson.UUIDText
+			null, errNull := sqljson.NullUUID{}.Value()
+			Convey("Then I should get text, bytes, and nil for NULL", func() {
+				So(errText, ShouldBeNil)
+				So(text, ShouldEqual, uuidText)
+				So(errBinary, ShouldBeNil)
+				So(binary, ShouldResemble, uuidBytes)
+				So(errNull, ShouldBeNil)
+				So(null, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestUUIDValidator(t *testing.T) {
+	type validatorStruct struct {
+		ID sqljson.NullUUID `validate:"required,uuid"`
+	}
+	Convey("Given a validator from NewValidator", t, func() {
+		validate := sqljson.NewValidator()
+		Convey("When I validate a valid and a null UUID", func() {
+			u, _ := sqljson.ParseUUID(uuidText)
+			errValid := validate.Struct(&validatorStruct{ID: sqljson.NewNullUUID(u)})
+			errNull := validate.Struct(&validatorStruct{})
+			Convey("Then only the null UUID should fail required", func() {
+				So(errValid, ShouldBeNil)
+				So(errNull, ShouldNotBeNil)
+				So(errNull.(validator.ValidationErrors)[0].Tag(), ShouldEqual, "required")
+			})
+		})
+	})
+}
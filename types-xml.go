@@ -0,0 +1,150 @@
+package sqljson
+
+import (
+	"encoding"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// XMLNullPolicy decides how NULL values are marshaled as XML elements.
+type XMLNullPolicy int
+
+const (
+	// XMLNullNil marshals NULL as an empty element with xsi:nil="true".
+	XMLNullNil XMLNullPolicy = iota
+	// XMLNullOmit leaves NULL elements out.
+	XMLNullOmit
+)
+
+// XMLNull is the policy used to marshal NULL XML elements. NULL attributes
+// are always left out. Both forms unmarshal back to NULL.
+var XMLNull = XMLNullNil
+
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// marshalXMLNull marshals a NULL element following XMLNull.
+func marshalXMLNull(e *xml.Encoder, start xml.StartElement) error {
+	if XMLNull == XMLNullOmit {
+		return nil
+	}
+	start.Attr = append(append([]xml.Attr{}, start.Attr...),
+		xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace},
+		xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"},
+	)
+	err := e.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// isXMLNil reports whether start carries xsi:nil="true". The xsi prefix is
+// accepted even when the document does not declare it.
+func isXMLNil(start xml.StartElement) bool {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "nil" && (attr.Name.Space == xsiNamespace || attr.Name.Space == "xsi") {
+			value := strings.TrimSpace(attr.Value)
+			return value == "true" || value == "1"
+		}
+	}
+	return false
+}
+
+// marshalXMLText marshals an element holding text, or NULL when valid is
+// false.
+func marshalXMLText(e *xml.Encoder, start xml.StartElement, text string, valid bool) error {
+	if !valid {
+		return marshalXMLNull(e, start)
+	}
+	return e.EncodeElement(text, start)
+}
+
+// unmarshalXMLText returns the text of an element. ok is false for an
+// xsi:nil element.
+func unmarshalXMLText(d *xml.Decoder, start xml.StartElement) (text string, ok bool, err error) {
+	if isXMLNil(start) {
+		return "", false, d.Skip()
+	}
+	err = d.DecodeElement(&text, &start)
+	if err != nil {
+		return "", false, err
+	}
+	return text, true, nil
+}
+
+// marshalXMLAttrText marshals an attribute holding text, leaving it out when
+// valid is false.
+func marshalXMLAttrText(name xml.Name, text string, valid bool) (xml.Attr, error) {
+	if !valid {
+		return xml.Attr{}, nil
+	}
+	return xml.Attr{Name: name, Value: text}, nil
+}
+
+// xmlText formats value as encoding/xml does for attributes.
+func xmlText(value interface{}) (string, error) {
+	if marshaler, ok := value.(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("sqljson: cannot marshal %T as an XML attribute", value)
+}
+
+// setXMLText parses text into the value pointed to by ptr, as encoding/xml
+// does for attributes.
+func setXMLText(ptr interface{}, text string) error {
+	if unmarshaler, ok := ptr.(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(text))
+	}
+	v := reflect.ValueOf(ptr).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+		return nil
+	case reflect.Bool:
+		value, err := strconv.ParseBool(strings.TrimSpace(text))
+		if err != nil {
+			return err
+		}
+		v.SetBool(value)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(strings.TrimSpace(text), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(value)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := strconv.ParseUint(strings.TrimSpace(text), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(value)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(strings.TrimSpace(text), v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(value)
+		return nil
+	}
+	return fmt.Errorf("sqljson: cannot unmarshal an XML attribute into %s", v.Type())
+}
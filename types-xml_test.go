@@ -0,0 +1,157 @@
+package sqljson_test
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/rhaseven7h/sqljson"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type xmlPerson struct {
+	XMLName   xml.Name                `xml:"person"`
+	ID        sqljson.NullInt64       `xml:"id,attr"`
+	Nickname  sqljson.NullString      `xml:"nickname,attr"`
+	Name      sqljson.NullString      `xml:"name"`
+	Age       sqljson.NullInt32       `xml:"age"`
+	Balance   sqljson.NullDecimal     `xml:"balance"`
+	BirthDate sqljson.NullTime        `xml:"birth_date"`
+	Followers sqljson.Null[uint64]    `xml:"followers"`
+	Active    sqljson.Optional[bool]  `xml:"active"`
+	Settings  sqljson.NullRawMessage  `xml:"settings"`
+	Comment   sqljson.EmptyNullString `xml:"comment"`
+}
+
+func TestXMLMarshal(t *testing.T) {
+	Convey("Given a struct with non-null and null sqljson values", t, func() {
+		person := xmlPerson{
+			ID:        sqljson.NewNullInt64(7),
+			Name:      sqljson.NewNullString("Gabriel"),
+			Balance:   sqljson.NewNullDecimal("12.50"),
+			BirthDate: sqljson.NewNullTime(time.Date(2017, time.May, 1, 10, 20, 30, 0, time.UTC)),
+			Active:    sqljson.OptionalFrom[bool](nil),
+			Comment:   sqljson.EmptyNullString{NullString: sqljson.NewNullString("")},
+		}
+		Convey("When I marshal it with the default policy", func() {
+			b, err := xml.Marshal(person)
+			Convey("Then NULL elements should carry xsi:nil, and NULL attributes and unset Optionals be left out", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `<person id="7">`+
+					`<name>Gabriel</name>`+
+					`<age xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></age>`+
+					`<balance>12.50</balance>`+
+					`<birth_date>2017-05-01T10:20:30Z</birth_date>`+
+					`<followers xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></followers>`+
+					`<active xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></active>`+
+					`<settings xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></settings>`+
+					`<comment xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></comment>`+
+					`</person>`)
+			})
+		})
+		Convey("When I marshal it with the omit policy", func() {
+			sqljson.XMLNull = sqljson.XMLNullOmit
+			b, err := xml.Marshal(person)
+			sqljson.XMLNull = sqljson.XMLNullNil
+			Convey("Then NULL elements should be left out", func() {
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `<person id="7">`+
+					`<name>Gabriel</name>`+
+					`<balance>12.50</balance>`+
+					`<birth_date>2017-05-01T10:20:30Z</birth_date>`+
+					`</person>`)
+			})
+		})
+	})
+}
+
+func TestXMLUnmarshal(t *testing.T) {
+	Convey("Given an XML document with values, xsi:nil elements and left out elements", t, func() {
+		data := []byte(`<person xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" id="7" nickname="">` +
+			`<name>Gabriel</name>` +
+			`<age xsi:nil="true"/>` +
+			`<balance> 12.50 </balance>` +
+			`<followers>18446744073709551615</followers>` +
+			`<active>true</active>` +
+			`<settings>{"theme": "dark"}</settings>` +
+			`<comment></comment>` +
+			`</person>`)
+		Convey("When I unmarshal it", func() {
+			person := xmlPerson{}
+			err := xml.Unmarshal(data, &person)
+			Convey("Then I should get the values, and NULL for the others", func() {
+				So(err, ShouldBeNil)
+				So(person.ID.Valid, ShouldBeTrue)
+				So(person.ID.Int64, ShouldEqual, 7)
+				So(person.Nickname.Valid, ShouldBeTrue)
+				So(person.Nickname.String, ShouldEqual, "")
+				So(person.Name.String, ShouldEqual, "Gabriel")
+				So(person.Age.Valid, ShouldBeFalse)
+				So(person.Balance.Decimal, ShouldEqual, "12.50")
+				So(person.BirthDate.Valid, ShouldBeFalse)
+				So(person.Followers.V, ShouldEqual, uint64(18446744073709551615))
+				So(person.Active.Set, ShouldBeTrue)
+				So(person.Active.V, ShouldBeTrue)
+				So(string(person.Settings.RawMessage), ShouldEqual, `{"theme": "dark"}`)
+				So(person.Comment.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given an XML document with an xsi:nil element whose prefix is not declared", t, func() {
+		data := []byte(`<person><name xsi:nil="1">ignored</name></person>`)
+		Convey("When I unmarshal it onto a non-null value", func() {
+			person := xmlPerson{Name: sqljson.NewNullString("Gabriel")}
+			err := xml.Unmarshal(data, &person)
+			Convey("Then it should be NULL", func() {
+				So(err, ShouldBeNil)
+				So(person.Name.Valid, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given XML documents with invalid values", t, func() {
+		cases := map[string]string{
+			`<person><age>old</age></person>`:                      "invalid syntax",
+			`<person id="x"></person>`:                             "invalid syntax",
+			`<person><balance>12,50</balance></person>`:            "invalid decimal",
+			`<person><settings>{oops}</settings></person>`:         "invalid JSON document",
+			`<person><birth_date>01/05/2017</birth_date></person>`: "cannot parse",
+		}
+		for data, message := range cases {
+			Convey("When I unmarshal "+data, func() {
+				err := xml.Unmarshal([]byte(data), &xmlPerson{})
+				Convey("Then I should get an error", func() {
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldContainSubstring, message)
+				})
+			})
+		}
+	})
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	Convey("Given a struct with non-null and null sqljson values", t, func() {
+		person := xmlPerson{
+			ID:        sqljson.NewNullInt64(7),
+			Age:       sqljson.NewNullInt32(40),
+			BirthDate: sqljson.NewNullTime(time.Date(2017, time.May, 1, 10, 20, 30, 0, time.UTC)),
+			Active:    sqljson.NewOptional(false),
+		}
+		Convey("When I marshal and unmarshal it with the default policy", func() {
+			b, errMarshal := xml.Marshal(person)
+			out := xmlPerson{}
+			err := xml.Unmarshal(b, &out)
+			Convey("Then I should get the same values and NULLs", func() {
+				So(errMarshal, ShouldBeNil)
+				So(err, ShouldBeNil)
+				So(out.ID, ShouldResemble, person.ID)
+				So(out.Age, ShouldResemble, person.Age)
+				So(out.Name.Valid, ShouldBeFalse)
+				So(out.Balance.Valid, ShouldBeFalse)
+				So(out.BirthDate.Time.Equal(person.BirthDate.Time), ShouldBeTrue)
+				So(out.Active.Set, ShouldBeTrue)
+				So(out.Active.Valid, ShouldBeTrue)
+				So(out.Settings.Valid, ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -0,0 +1,75 @@
+package sqljson
+
+import (
+	"cmp"
+	"reflect"
+	"time"
+
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+// dateParam parses the param of a date tag, a date in DateLayout or "today".
+func dateParam(param string) (Date, error) {
+	if param == "today" {
+		return DateOf(time.Now()), nil
+	}
+	return ParseDate(param)
+}
+
+// compareDate returns a validation comparing a date field with the date of
+// the param, valid when ok returns true for the result.
+func compareDate(ok func(c int) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		if fl.Field().Kind() != reflect.String {
+			return false
+		}
+		date, err := ParseDate(fl.Field().String())
+		if err != nil {
+			return false
+		}
+		param, err := dateParam(fl.Param())
+		return err == nil && ok(date.Compare(param))
+	}
+}
+
+// compareTimeOfDay returns a validation comparing a time of day field with
+// the time of day of the param, valid when ok returns true for the result.
+func compareTimeOfDay(ok func(c int) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		if fl.Field().Kind() != reflect.String {
+			return false
+		}
+		timeOfDay, err := ParseTimeOfDay(fl.Field().String())
+		if err != nil {
+			return false
+		}
+		param, err := ParseTimeOfDay(fl.Param())
+		return err == nil && ok(timeOfDay.Compare(param))
+	}
+}
+
+// compareDuration returns a validation comparing a duration field with the
+// Go duration of the param, valid when ok returns true for the result.
+func compareDuration(ok func(c int) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		if fl.Field().Kind() != reflect.Int64 {
+			return false
+		}
+		param, err := time.ParseDuration(fl.Param())
+		return err == nil && ok(cmp.Compare(fl.Field().Int(), int64(param)))
+	}
+}
+
+// dateValidations are the tags registered by RegisterValidator for NullDate,
+// NullTimeOfDay and NullDuration fields. Dates are given in DateLayout, or as
+// "today", times of day as in ParseTimeOfDay, and durations as Go durations
+// such as "90m".
+var dateValidations = map[string]validator.Func{
+	"date_min": compareDate(func(c int) bool { return c >= 0 }),
+	"date_max": compareDate(func(c int) bool { return c <= 0 }),
+	"time_min": compareTimeOfDay(func(c int) bool { return c >= 0 }),
+	"time_max": compareTimeOfDay(func(c int) bool { return c <= 0 }),
+
+	"duration_min": compareDuration(func(c int) bool { return c >= 0 }),
+	"duration_max": compareDuration(func(c int) bool { return c <= 0 }),
+}
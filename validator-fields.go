@@ -0,0 +1,146 @@
+package sqljson
+
+import (
+	"cmp"
+	"reflect"
+	"strings"
+	"time"
+
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+// unwrapValue returns the value of v, unwrapping pointers and sqljson types.
+func unwrapValue(v reflect.Value) interface{} {
+	v = reflect.Indirect(v)
+	if value, ok := v.Interface().(validateValuer); ok {
+		return value.ValidateValue()
+	}
+	return v.Interface()
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUintKind(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+func isFloatKind(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+// numberValue returns v as a float64 when it is a number.
+func numberValue(v reflect.Value) (float64, bool) {
+	switch {
+	case isIntKind(v.Kind()):
+		return float64(v.Int()), true
+	case isUintKind(v.Kind()):
+		return float64(v.Uint()), true
+	case isFloatKind(v.Kind()):
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// compareValues compares a and b, which may be numbers of different types,
+// strings, booleans or times. It reports false when they cannot be compared.
+func compareValues(a, b interface{}) (int, bool) {
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Compare(tb), true
+		}
+		return 0, false
+	}
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch {
+	case isIntKind(va.Kind()) && isIntKind(vb.Kind()):
+		return cmp.Compare(va.Int(), vb.Int()), true
+	case isUintKind(va.Kind()) && isUintKind(vb.Kind()):
+		return cmp.Compare(va.Uint(), vb.Uint()), true
+	case va.Kind() == reflect.String && vb.Kind() == reflect.String:
+		return cmp.Compare(va.String(), vb.String()), true
+	case va.Kind() == reflect.Bool && vb.Kind() == reflect.Bool:
+		if va.Bool() == vb.Bool() {
+			return 0, true
+		}
+		if vb.Bool() {
+			return -1, true
+		}
+		return 1, true
+	}
+	fa, okA := numberValue(va)
+	fb, okB := numberValue(vb)
+	if okA && okB {
+		return cmp.Compare(fa, fb), true
+	}
+	return 0, false
+}
+
+// compareField returns a validation comparing a field with the field named
+// by the param, valid when ok returns true for the result. As in SQL CHECK
+// constraints, the validation passes when either field is NULL.
+func compareField(ok func(c int) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		if isNullField(fl) {
+			return true
+		}
+		other, found := paramField(fl, fl.Param())
+		if !found {
+			return false
+		}
+		if isNullValue(other) {
+			return true
+		}
+		c, comparable := compareValues(fl.Field().Interface(), unwrapValue(other))
+		return comparable && ok(c)
+	}
+}
+
+// notNullFields counts the fields named in param, separated by spaces, that
+// are not NULL. It reports false when a field does not exist.
+func notNullFields(fl validator.FieldLevel) (int, bool) {
+	count := 0
+	for _, name := range strings.Fields(fl.Param()) {
+		field, found := paramField(fl, name)
+		if !found {
+			return 0, false
+		}
+		if !isNullValue(field) {
+			count++
+		}
+	}
+	return count, true
+}
+
+// validateRequiredWith is the required_with validation.
+func validateRequiredWith(fl validator.FieldLevel) bool {
+	count, ok := notNullFields(fl)
+	return ok && (count == 0 || !isNullField(fl))
+}
+
+// validateExcludedWith is the excluded_with validation.
+func validateExcludedWith(fl validator.FieldLevel) bool {
+	count, ok := notNullFields(fl)
+	return ok && (count == 0 || isNullField(fl))
+}
+
+// validateOneOfNotNull is the one_of_notnull validation.
+func validateOneOfNotNull(fl validator.FieldLevel) bool {
+	count, ok := notNullFields(fl)
+	if !isNullField(fl) {
+		count++
+	}
+	return ok && count == 1
+}
+
+// fieldValidations are the cross-field validations registered by
+// RegisterNullTags.
+var fieldValidations = map[string]validator.Func{
+	"null_gtfield":   compareField(func(c int) bool { return c > 0 }),
+	"null_ltfield":   compareField(func(c int) bool { return c < 0 }),
+	"null_eqfield":   compareField(func(c int) bool { return c == 0 }),
+	"required_with":  validateRequiredWith,
+	"excluded_with":  validateExcludedWith,
+	"one_of_notnull": validateOneOfNotNull,
+}
@@ -0,0 +1,162 @@
+package sqljson_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rhaseven7h/sqljson"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type fieldsBooking struct {
+	StartDate sqljson.NullTime    `json:"start_date"`
+	EndDate   sqljson.NullTime    `json:"end_date" validate:"null_gtfield=StartDate"`
+	MinGuests sqljson.NullInt32   `json:"min_guests"`
+	MaxGuests sqljson.NullInt64   `json:"max_guests" validate:"null_gtfield=MinGuests"`
+	Deposit   sqljson.NullFloat64 `json:"deposit" validate:"null_ltfield=Price"`
+	Price     sqljson.NullDecimal `json:"price"`
+	Code      sqljson.NullString  `json:"code"`
+	CodeAgain sqljson.NullString  `json:"code_again" validate:"null_eqfield=Code"`
+}
+
+type fieldsContact struct {
+	Email    sqljson.NullString `json:"email" validate:"one_of_notnull=Phone"`
+	Phone    sqljson.NullString `json:"phone"`
+	Street   sqljson.NullString `json:"street"`
+	City     sqljson.NullString `json:"city" validate:"required_with=Street"`
+	Guest    sqljson.NullBool   `json:"guest"`
+	Password sqljson.NullString `json:"password" validate:"excluded_with=Guest"`
+}
+
+type fieldsPeriod struct {
+	Start sqljson.NullDate `json:"start"`
+}
+
+type fieldsEvent struct {
+	Period   fieldsPeriod     `json:"period"`
+	Deadline sqljson.NullDate `json:"deadline" validate:"null_gtfield=Period.Start"`
+	Short    string           `json:"short"`
+	Long     string           `json:"long" validate:"gtfield=Short"`
+	Nested   fieldsPeriod     `json:"nested"`
+	Copy     fieldsPeriod     `json:"copy" validate:"eqfield=Nested"`
+}
+
+func TestCrossFieldTags(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	Convey("Given a validator with RegisterNullTags", t, func() {
+		validate := nullTagsValidator()
+		Convey("When compared fields hold values in order", func() {
+			err := validate.Struct(&fieldsBooking{
+				StartDate: sqljson.NewNullTime(start),
+				EndDate:   sqljson.NewNullTime(start.AddDate(0, 0, 1)),
+				MinGuests: sqljson.NewNullInt32(2),
+				MaxGuests: sqljson.NewNullInt64(4),
+				Deposit:   sqljson.NewNullFloat64(10.5),
+				Price:     sqljson.NewNullDecimal("99.90"),
+				Code:      sqljson.NewNullString("ABC"),
+				CodeAgain: sqljson.NewNullString("ABC"),
+			})
+			Convey("Then they should pass, across types", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When compared fields hold values out of order", func() {
+			err := validate.Struct(&fieldsBooking{
+				StartDate: sqljson.NewNullTime(start),
+				EndDate:   sqljson.NewNullTime(start),
+				MinGuests: sqljson.NewNullInt32(4),
+				MaxGuests: sqljson.NewNullInt64(2),
+				Deposit:   sqljson.NewNullFloat64(100),
+				Price:     sqljson.NewNullDecimal("99.90"),
+				Code:      sqljson.NewNullString("ABC"),
+				CodeAgain: sqljson.NewNullString("ABD"),
+			})
+			Convey("Then they should fail", func() {
+				So(validationTags(err), ShouldResemble, map[string]string{
+					"end_date":   "null_gtfield",
+					"max_guests": "null_gtfield",
+					"deposit":    "null_ltfield",
+					"code_again": "null_eqfield",
+				})
+			})
+		})
+		Convey("When either compared field is NULL", func() {
+			err := validate.Struct(&fieldsBooking{
+				StartDate: sqljson.NewNullTime(start),
+				MaxGuests: sqljson.NewNullInt64(2),
+				Code:      sqljson.NewNullString("ABC"),
+			})
+			Convey("Then they should pass", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When exactly one of email and phone is set, with a full address", func() {
+			err := validate.Struct(&fieldsContact{
+				Phone:  sqljson.NewNullString("555-0100"),
+				Street: sqljson.NewNullString("Main St"),
+				City:   sqljson.NewNullString("Springfield"),
+			})
+			Convey("Then it should pass", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When neither email nor phone is set", func() {
+			err := validate.Struct(&fieldsContact{})
+			Convey("Then one_of_notnull should fail", func() {
+				So(validationTags(err), ShouldResemble, map[string]string{"email": "one_of_notnull"})
+			})
+		})
+		Convey("When fields conflict with the fields they depend on", func() {
+			err := validate.Struct(&fieldsContact{
+				Email:    sqljson.NewNullString("user@server.tld"),
+				Phone:    sqljson.NewNullString("555-0100"),
+				Street:   sqljson.NewNullString("Main St"),
+				Guest:    sqljson.NewNullBool(false),
+				Password: sqljson.NewNullString("secret"),
+			})
+			Convey("Then every presence tag should fail", func() {
+				So(validationTags(err), ShouldResemble, map[string]string{
+					"email":    "one_of_notnull",
+					"city":     "required_with",
+					"password": "excluded_with",
+				})
+			})
+		})
+	})
+	Convey("Given a validator with RegisterNullTags, and plain fields using validator's own tags", t, func() {
+		validate := nullTagsValidator()
+		start, _ := sqljson.ParseDate("2024-05-01")
+		before, _ := sqljson.ParseDate("2024-04-01")
+		period := fieldsPeriod{Start: sqljson.NewNullDate(start)}
+		Convey("When the plain fields follow validator's own rules", func() {
+			err := validate.Struct(&fieldsEvent{Period: period, Short: "b", Long: "aa", Nested: period, Copy: period})
+			Convey("Then gtfield should compare string lengths, and eqfield whole structs", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When a dotted param names a field that is out of order", func() {
+			err := validate.Struct(&fieldsEvent{Period: period, Deadline: sqljson.NewNullDate(before), Long: "a"})
+			Convey("Then it should resolve the nested field", func() {
+				So(validationTags(err), ShouldResemble, map[string]string{"deadline": "null_gtfield"})
+			})
+		})
+	})
+	Convey("Given a validator with RegisterNullTags, and a param naming an unexported field", t, func() {
+		type hiddenStart struct {
+			start sqljson.NullInt64
+			End   sqljson.NullInt64 `json:"end" validate:"null_gtfield=start"`
+		}
+		validate := nullTagsValidator()
+		Convey("When I validate it", func() {
+			var err error
+			run := func() {
+				err = validate.Struct(&hiddenStart{start: sqljson.NewNullInt64(1), End: sqljson.NewNullInt64(2)})
+			}
+			Convey("Then it should not panic, and fail as if the field did not exist", func() {
+				So(run, ShouldNotPanic)
+				So(validationTags(err), ShouldResemble, map[string]string{"end": "null_gtfield"})
+			})
+		})
+	})
+}
@@ -0,0 +1,146 @@
+package sqljson
+
+import (
+	"reflect"
+	"strings"
+
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+// nullValidateValue is what a NULL field validates as once RegisterNullTags
+// is called: a zero float, told apart from a valid 0 by its type.
+type nullValidateValue float64
+
+var nullValidateValueType = reflect.TypeOf(nullValidateValue(0))
+
+// nullableValidateValuer is the custom type func installed by
+// RegisterNullTags. Validator fails NULL fields on their first tag without
+// running it, unless it is omitempty, so NULL is returned as a
+// nullValidateValue instead. Non-NULL values are returned as pointers, so
+// that omitempty and required look at the Valid flag and not at the value.
+func nullableValidateValuer(field reflect.Value) interface{} {
+	value := ValidateValuer(field)
+	if value == nil {
+		return nullValidateValue(0)
+	}
+	ptr := reflect.New(reflect.TypeOf(value))
+	ptr.Elem().Set(reflect.ValueOf(value))
+	return ptr.Interface()
+}
+
+// isNullField reports whether the field validated by fl is NULL.
+func isNullField(fl validator.FieldLevel) bool {
+	return fl.Field().Type() == nullValidateValueType
+}
+
+// isNullValue reports whether v is NULL: a nil pointer or interface, or a
+// sqljson type holding no value.
+func isNullValue(v reflect.Value) bool {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return true
+	}
+	if !v.CanInterface() {
+		return false
+	}
+	if value, ok := v.Interface().(validateValuer); ok {
+		return value.ValidateValue() == nil
+	}
+	return false
+}
+
+// paramField returns the field named name in the struct holding the field
+// validated by fl. The name may be a dotted path through nested structs,
+// such as "Period.Start". Unexported fields are not found.
+func paramField(fl validator.FieldLevel, name string) (reflect.Value, bool) {
+	field := fl.Parent()
+	for _, part := range strings.Split(name, ".") {
+		for field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+			if field.IsNil() {
+				return reflect.Value{}, false
+			}
+			field = field.Elem()
+		}
+		if field.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		field = field.FieldByName(part)
+		if !field.IsValid() || !field.CanInterface() {
+			return reflect.Value{}, false
+		}
+	}
+	return field, true
+}
+
+// validateNotNull is the notnull validation.
+func validateNotNull(fl validator.FieldLevel) bool {
+	return !isNullField(fl)
+}
+
+// validateIsNull is the isnull validation.
+func validateIsNull(fl validator.FieldLevel) bool {
+	return isNullField(fl)
+}
+
+// validateNullIf is the null_if validation.
+func validateNullIf(fl validator.FieldLevel) bool {
+	if isNullField(fl) {
+		return true
+	}
+	other, ok := paramField(fl, fl.Param())
+	return ok && !isNullValue(other)
+}
+
+// RegisterNullTags registers with v validation tags checking the Valid flag
+// of sqljson fields, apart from their value:
+//
+//   - notnull: the field is not NULL, whatever its value, so a valid false
+//     or 0 passes.
+//   - isnull: the field is NULL.
+//   - null_if=Field: the field is NULL when Field, in the same struct, is
+//     NULL, so it can only be set along with Field. The converse, NULL when
+//     Field is not NULL, is excluded_with.
+//
+// It also registers cross-field tags, whose param names fields of the same
+// struct, or of structs nested in it as in "Period.Start", comparing the
+// values inside sqljson types:
+//
+//   - null_gtfield, null_ltfield and null_eqfield: the field is greater
+//     than, less than or equal to the other field. As in SQL CHECK
+//     constraints, they pass when either field is NULL, so "end_date is NULL
+//     or after start_date" is written null_gtfield=StartDate. Numbers of
+//     different types, strings, times and booleans can be compared.
+//     Validator's own gtfield, ltfield and eqfield are left as they are.
+//   - required_with=Field1 Field2: the field is not NULL when any of the
+//     other fields is not NULL.
+//   - excluded_with=Field1 Field2: the field is NULL when any of the other
+//     fields is not NULL.
+//   - one_of_notnull=Field1 Field2: exactly one of the field and the other
+//     fields is not NULL.
+//
+// RegisterNullTags also makes omitempty skip NULL fields only, and required
+// accept any non-NULL value. Other tags validate NULL as a zero value.
+// Types given are registered as in RegisterValidator.
+func RegisterNullTags(v *validator.Validate, types ...interface{}) error {
+	v.RegisterCustomTypeFunc(nullableValidateValuer, allValidatorTypes(types)...)
+	validations := map[string]validator.Func{
+		"notnull": validateNotNull,
+		"isnull":  validateIsNull,
+		"null_if": validateNullIf,
+	}
+	for tag, fn := range fieldValidations {
+		validations[tag] = fn
+	}
+	for tag, fn := range validations {
+		err := v.RegisterValidation(tag, fn)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
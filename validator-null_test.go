@@ -0,0 +1,91 @@
+package sqljson_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rhaseven7h/sqljson"
+
+	validator "gopkg.in/go-playground/validator.v9"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type nullTagsTicket struct {
+	IsAdmin      sqljson.NullBool         `json:"is_admin" validate:"notnull"`
+	Followers    sqljson.NullInt64        `json:"followers" validate:"required"`
+	DeletedAt    sqljson.NullTime         `json:"deleted_at" validate:"isnull"`
+	ClosedAt     sqljson.NullTime         `json:"closed_at"`
+	ReopenReason sqljson.Optional[string] `json:"reopen_reason" validate:"omitempty,null_if=ClosedAt"`
+	Rating       sqljson.Null[uint8]      `json:"rating" validate:"omitempty,max=5"`
+}
+
+func nullTagsValidator() *validator.Validate {
+	v := sqljson.NewValidator()
+	So(sqljson.RegisterNullTags(v, sqljson.Null[uint8]{}), ShouldBeNil)
+	return v
+}
+
+func validationTags(err error) map[string]string {
+	tags := map[string]string{}
+	if err != nil {
+		for _, fe := range err.(validator.ValidationErrors) {
+			tags[fe.Field()] = fe.Tag()
+		}
+	}
+	return tags
+}
+
+func TestRegisterNullTags(t *testing.T) {
+	Convey("Given a validator with RegisterNullTags", t, func() {
+		validate := nullTagsValidator()
+		Convey("When fields hold valid zero values", func() {
+			err := validate.Struct(&nullTagsTicket{
+				IsAdmin:      sqljson.NewNullBool(false),
+				Followers:    sqljson.NewNullInt64(0),
+				ClosedAt:     sqljson.NewNullTime(time.Now()),
+				ReopenReason: sqljson.NewOptional(""),
+				Rating:       sqljson.NewNull[uint8](0),
+			})
+			Convey("Then notnull and required should accept them", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When fields are NULL", func() {
+			err := validate.Struct(&nullTagsTicket{})
+			Convey("Then only notnull and required should fail", func() {
+				So(validationTags(err), ShouldResemble, map[string]string{
+					"is_admin":  "notnull",
+					"followers": "required",
+				})
+			})
+		})
+		Convey("When fields tagged isnull and null_if are not NULL, while the null_if field's other field is NULL", func() {
+			err := validate.Struct(&nullTagsTicket{
+				IsAdmin:      sqljson.NewNullBool(true),
+				Followers:    sqljson.NewNullInt64(1),
+				DeletedAt:    sqljson.NewNullTime(time.Now()),
+				ReopenReason: sqljson.NewOptional("mistake"),
+				Rating:       sqljson.NewNull[uint8](6),
+			})
+			Convey("Then isnull, null_if and the value tags should fail", func() {
+				So(validationTags(err), ShouldResemble, map[string]string{
+					"deleted_at":    "isnull",
+					"reopen_reason": "null_if",
+					"rating":        "max",
+				})
+			})
+		})
+		Convey("When a null_if field is set along with the other field", func() {
+			err := validate.Struct(&nullTagsTicket{
+				IsAdmin:      sqljson.NewNullBool(true),
+				Followers:    sqljson.NewNullInt64(1),
+				ClosedAt:     sqljson.NewNullTime(time.Now()),
+				ReopenReason: sqljson.NewOptional("mistake"),
+			})
+			Convey("Then it should pass", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
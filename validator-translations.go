@@ -0,0 +1,341 @@
+package sqljson
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/fr"
+	"github.com/go-playground/locales/nl"
+	ut "github.com/go-playground/universal-translator"
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+// translatedTags are the validation tags RegisterTranslations translates.
+// Other tags get the locale's default message.
+var translatedTags = []string{
+	"required", "min", "max", "len", "eq", "ne", "gt", "gte", "lt", "lte", "email", "url", "oneof",
+	"date_min", "date_max", "time_min", "time_max", "duration_min", "duration_max",
+	"notnull", "isnull", "null_if",
+	"null_gtfield", "null_ltfield", "null_eqfield", "required_with", "excluded_with", "one_of_notnull",
+}
+
+// fieldParamTags are the translated tags whose param names other fields of
+// the struct, written in messages by their JSON names.
+var fieldParamTags = map[string]bool{
+	"null_if": true, "null_gtfield": true, "null_ltfield": true, "null_eqfield": true,
+	"required_with": true, "excluded_with": true, "one_of_notnull": true,
+}
+
+// validationMessages are the validation messages of every supported locale.
+// {0} is the field name and {1} the tag parameter. Tags checking lengths
+// have a message per kind of field: _string, _items and _number.
+var validationMessages = map[string]map[string]string{
+	"en": {
+		"required":       "{0} is a required field",
+		"min_string":     "{0} must be at least {1} characters in length",
+		"min_items":      "{0} must contain at least {1} items",
+		"min_number":     "{0} must be {1} or greater",
+		"max_string":     "{0} must be a maximum of {1} characters in length",
+		"max_items":      "{0} must contain at most {1} items",
+		"max_number":     "{0} must be {1} or less",
+		"len_string":     "{0} must be {1} characters in length",
+		"len_items":      "{0} must contain {1} items",
+		"len_number":     "{0} must be equal to {1}",
+		"eq":             "{0} is not equal to {1}",
+		"ne":             "{0} should not be equal to {1}",
+		"gt":             "{0} must be greater than {1}",
+		"gte":            "{0} must be greater than or equal to {1}",
+		"lt":             "{0} must be less than {1}",
+		"lte":            "{0} must be less than or equal to {1}",
+		"email":          "{0} must be a valid email address",
+		"url":            "{0} must be a valid URL",
+		"oneof":          "{0} must be one of [{1}]",
+		"date_min":       "{0} must be {1} or later",
+		"date_max":       "{0} must be {1} or earlier",
+		"time_min":       "{0} must be {1} or later",
+		"time_max":       "{0} must be {1} or earlier",
+		"duration_min":   "{0} must be at least {1}",
+		"duration_max":   "{0} must be at most {1}",
+		"notnull":        "{0} must not be null",
+		"isnull":         "{0} must be null",
+		"null_if":        "{0} must be null when {1} is null",
+		"null_gtfield":   "{0} must be greater than {1}",
+		"null_ltfield":   "{0} must be less than {1}",
+		"null_eqfield":   "{0} must be equal to {1}",
+		"required_with":  "{0} is required when {1} is set",
+		"excluded_with":  "{0} must be null when {1} is set",
+		"one_of_notnull": "exactly one of {0} and {1} must be set",
+		"default":        "{0} is not valid",
+	},
+	"fr": {
+		"required":       "{0} est un champ obligatoire",
+		"min_string":     "{0} doit faire au moins {1} caractères",
+		"min_items":      "{0} doit contenir au moins {1} éléments",
+		"min_number":     "{0} doit être égal à {1} ou plus",
+		"max_string":     "{0} doit faire au maximum {1} caractères",
+		"max_items":      "{0} doit contenir au maximum {1} éléments",
+		"max_number":     "{0} doit être égal à {1} ou moins",
+		"len_string":     "{0} doit faire {1} caractères",
+		"len_items":      "{0} doit contenir {1} éléments",
+		"len_number":     "{0} doit être égal à {1}",
+		"eq":             "{0} n'est pas égal à {1}",
+		"ne":             "{0} ne doit pas être égal à {1}",
+		"gt":             "{0} doit être supérieur à {1}",
+		"gte":            "{0} doit être supérieur ou égal à {1}",
+		"lt":             "{0} doit être inférieur à {1}",
+		"lte":            "{0} doit être inférieur ou égal à {1}",
+		"email":          "{0} doit être une adresse email valide",
+		"url":            "{0} doit être une URL valide",
+		"oneof":          "{0} doit être l'une des valeurs [{1}]",
+		"date_min":       "{0} doit être le {1} ou après",
+		"date_max":       "{0} doit être le {1} ou avant",
+		"time_min":       "{0} doit être à {1} ou après",
+		"time_max":       "{0} doit être à {1} ou avant",
+		"duration_min":   "{0} doit durer au moins {1}",
+		"duration_max":   "{0} doit durer au plus {1}",
+		"notnull":        "{0} ne doit pas être nul",
+		"isnull":         "{0} doit être nul",
+		"null_if":        "{0} doit être nul quand {1} est nul",
+		"null_gtfield":   "{0} doit être supérieur à {1}",
+		"null_ltfield":   "{0} doit être inférieur à {1}",
+		"null_eqfield":   "{0} doit être égal à {1}",
+		"required_with":  "{0} est obligatoire quand {1} est renseigné",
+		"excluded_with":  "{0} doit être nul quand {1} est renseigné",
+		"one_of_notnull": "un seul parmi {0} et {1} doit être renseigné",
+		"default":        "{0} n'est pas valide",
+	},
+	"nl": {
+		"required":       "{0} is een verplicht veld",
+		"min_string":     "{0} moet minimaal {1} tekens lang zijn",
+		"min_items":      "{0} moet minimaal {1} items bevatten",
+		"min_number":     "{0} moet {1} of groter zijn",
+		"max_string":     "{0} mag maximaal {1} tekens lang zijn",
+		"max_items":      "{0} mag maximaal {1} items bevatten",
+		"max_number":     "{0} moet {1} of kleiner zijn",
+		"len_string":     "{0} moet {1} tekens lang zijn",
+		"len_items":      "{0} moet {1} items bevatten",
+		"len_number":     "{0} moet gelijk zijn aan {1}",
+		"eq":             "{0} is niet gelijk aan {1}",
+		"ne":             "{0} mag niet gelijk zijn aan {1}",
+		"gt":             "{0} moet groter zijn dan {1}",
+		"gte":            "{0} moet groter dan of gelijk aan {1} zijn",
+		"lt":             "{0} moet kleiner zijn dan {1}",
+		"lte":            "{0} moet kleiner dan of gelijk aan {1} zijn",
+		"email":          "{0} moet een geldig e-mailadres zijn",
+		"url":            "{0} moet een geldige URL zijn",
+		"oneof":          "{0} moet een van de volgende zijn [{1}]",
+		"date_min":       "{0} moet op of na {1} zijn",
+		"date_max":       "{0} moet op of voor {1} zijn",
+		"time_min":       "{0} moet om of na {1} zijn",
+		"time_max":       "{0} moet om of voor {1} zijn",
+		"duration_min":   "{0} moet minstens {1} duren",
+		"duration_max":   "{0} mag hoogstens {1} duren",
+		"notnull":        "{0} mag niet leeg zijn",
+		"isnull":         "{0} moet leeg zijn",
+		"null_if":        "{0} moet leeg zijn als {1} leeg is",
+		"null_gtfield":   "{0} moet groter zijn dan {1}",
+		"null_ltfield":   "{0} moet kleiner zijn dan {1}",
+		"null_eqfield":   "{0} moet gelijk zijn aan {1}",
+		"required_with":  "{0} is verplicht als {1} is ingevuld",
+		"excluded_with":  "{0} moet leeg zijn als {1} is ingevuld",
+		"one_of_notnull": "precies één van {0} en {1} moet ingevuld zijn",
+		"default":        "{0} is ongeldig",
+	},
+}
+
+// NewTranslator returns a universal translator supporting every locale
+// sqljson has validation messages for, falling back to English.
+func NewTranslator() *ut.UniversalTranslator {
+	return ut.New(en.New(), en.New(), fr.New(), nl.New())
+}
+
+// messageKey returns the translation key of a validation message.
+func messageKey(key string) string {
+	return "sqljson." + key
+}
+
+// RegisterTranslations registers with v the validation messages of trans,
+// whose locale must be one of en, fr or nl.
+func RegisterTranslations(v *validator.Validate, trans ut.Translator) error {
+	messages, ok := validationMessages[trans.Locale()]
+	if !ok {
+		return fmt.Errorf("sqljson: no validation messages for locale %q", trans.Locale())
+	}
+	for key, text := range messages {
+		err := trans.Add(messageKey(key), text, true)
+		if err != nil {
+			return err
+		}
+	}
+	noop := func(ut.Translator) error { return nil }
+	for _, tag := range translatedTags {
+		err := v.RegisterTranslation(tag, trans, noop, translateFieldError)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// translateFieldError is the validator.TranslationFunc of every tag
+// registered by RegisterTranslations.
+func translateFieldError(trans ut.Translator, fe validator.FieldError) string {
+	key := fe.Tag()
+	switch key {
+	case "min", "max", "len":
+		switch fe.Kind() {
+		case reflect.String:
+			key += "_string"
+		case reflect.Slice, reflect.Map, reflect.Array:
+			key += "_items"
+		default:
+			key += "_number"
+		}
+	}
+	message, err := trans.T(messageKey(key), fe.Field(), fe.Param())
+	if err != nil {
+		return defaultMessage(trans, fe)
+	}
+	return message
+}
+
+// jsonParamError is a validator.FieldError whose param names fields by
+// their JSON names.
+type jsonParamError struct {
+	validator.FieldError
+	param string
+}
+
+// Param //
+func (fe jsonParamError) Param() string {
+	return fe.param
+}
+
+// jsonParam returns the field names of param, relative to struct type
+// parent, by their JSON names, separated by commas.
+func jsonParam(parent reflect.Type, param string) string {
+	names := strings.Fields(param)
+	for i, name := range names {
+		names[i] = jsonFieldPath(parent, name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// parentStructType returns the type of the struct holding the field of fe,
+// following its struct namespace from top, the type of the validated
+// struct. It reports false when the namespace does not start at top.
+func parentStructType(top reflect.Type, fe validator.FieldError) (reflect.Type, bool) {
+	if top == nil {
+		return nil, false
+	}
+	parts := strings.Split(fe.StructNamespace(), ".")
+	t := indirectType(top)
+	if t.Kind() != reflect.Struct || t.Name() != parts[0] {
+		return nil, false
+	}
+	for _, part := range parts[1 : len(parts)-1] {
+		name, indexes := part, 0
+		if i := strings.Index(part, "["); i >= 0 {
+			name, indexes = part[:i], strings.Count(part, "[")
+		}
+		field, found := indirectType(t).FieldByName(name)
+		if !found {
+			return nil, false
+		}
+		t = field.Type
+		for ; indexes > 0; indexes-- {
+			switch t = indirectType(t); t.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				t = t.Elem()
+			default:
+				return nil, false
+			}
+		}
+	}
+	t = indirectType(t)
+	return t, t.Kind() == reflect.Struct
+}
+
+// jsonFieldPath returns the dotted path of Go field names name, relative to
+// struct type t, as JSON names.
+func jsonFieldPath(t reflect.Type, name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		t = indirectType(t)
+		if t.Kind() != reflect.Struct {
+			break
+		}
+		field, found := t.FieldByName(part)
+		if !found {
+			break
+		}
+		if jsonName := jsonTagName(field); jsonName != "" {
+			parts[i] = jsonName
+		}
+		t = field.Type
+	}
+	return strings.Join(parts, ".")
+}
+
+// indirectType returns the type pointed to by t, when t is a pointer.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// defaultMessage returns the default validation message of trans, or the
+// validator's own message when trans has none.
+func defaultMessage(trans ut.Translator, fe validator.FieldError) string {
+	message, err := trans.T(messageKey("default"), fe.Field())
+	if err != nil {
+		return fmt.Sprint(fe)
+	}
+	return message
+}
+
+// TranslateErrors converts err, as returned by validating the struct s with
+// a validator from NewValidator, into translated messages keyed by the JSON
+// path of each field, such as "contact_email" or "address.city", ready to
+// be returned as an API error body. The type of s, a struct or a pointer to
+// one, gives the JSON names of the fields named by cross-field tags; with a
+// nil s they keep their Go names. Messages of tags without a translation
+// fall back to the locale's default message. Errors other than
+// validator.ValidationErrors are returned as-is.
+func TranslateErrors(err error, trans ut.Translator, s interface{}) (map[string]string, error) {
+	if err == nil {
+		return nil, nil
+	}
+	errs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return nil, err
+	}
+	top := reflect.TypeOf(s)
+	messages := make(map[string]string, len(errs))
+	for _, fe := range errs {
+		if fieldParamTags[fe.Tag()] {
+			if parent, ok := parentStructType(top, fe); ok {
+				fe = jsonParamError{FieldError: fe, param: jsonParam(parent, fe.Param())}
+				messages[fieldPath(fe.Namespace())] = translateFieldError(trans, fe)
+				continue
+			}
+		}
+		message := fe.Translate(trans)
+		if message == fmt.Sprint(fe) {
+			message = defaultMessage(trans, fe)
+		}
+		messages[fieldPath(fe.Namespace())] = message
+	}
+	return messages, nil
+}
+
+// fieldPath strips the name of the top-level struct from a namespace.
+func fieldPath(namespace string) string {
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
@@ -0,0 +1,184 @@
+package sqljson_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rhaseven7h/sqljson"
+
+	"github.com/go-playground/locales/en_CA"
+	ut "github.com/go-playground/universal-translator"
+	validator "gopkg.in/go-playground/validator.v9"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type translatedAddress struct {
+	City sqljson.NullString `json:"city" validate:"required"`
+}
+
+type translatedSupplier struct {
+	ContactEmail sqljson.NullString  `json:"contact_email" validate:"required,email"`
+	Name         sqljson.NullString  `json:"name" validate:"omitempty,min=3"`
+	Followers    sqljson.NullInt64   `json:"followers" validate:"omitempty,max=10"`
+	Tags         []string            `json:"tags" validate:"min=1"`
+	Code         string              `validate:"alpha"`
+	Address      translatedAddress   `json:"address"`
+	BankBalance  sqljson.NullFloat64 `json:"bank_balance" validate:"omitempty,gte=0"`
+}
+
+func translationsFor(locale string) (*validator.Validate, ut.Translator) {
+	v := sqljson.NewValidator()
+	trans, _ := sqljson.NewTranslator().GetTranslator(locale)
+	So(sqljson.RegisterTranslations(v, trans), ShouldBeNil)
+	return v, trans
+}
+
+func invalidSupplier() *translatedSupplier {
+	return &translatedSupplier{
+		ContactEmail: sqljson.NewNullString("not-an-email"),
+		Name:         sqljson.NewNullString("ab"),
+		Followers:    sqljson.NewNullInt64(11),
+		Code:         "123",
+		BankBalance:  sqljson.NewNullFloat64(-1),
+	}
+}
+
+func TestTranslateErrors(t *testing.T) {
+	cases := map[string]map[string]string{
+		"en": {
+			"contact_email": "contact_email must be a valid email address",
+			"name":          "name must be at least 3 characters in length",
+			"followers":     "followers must be 10 or less",
+			"tags":          "tags must contain at least 1 items",
+			"Code":          "Code is not valid",
+			"address.city":  "city is a required field",
+			"bank_balance":  "bank_balance must be greater than or equal to 0",
+		},
+		"fr": {
+			"contact_email": "contact_email doit être une adresse email valide",
+			"name":          "name doit faire au moins 3 caractères",
+			"followers":     "followers doit être égal à 10 ou moins",
+			"tags":          "tags doit contenir au moins 1 éléments",
+			"Code":          "Code n'est pas valide",
+			"address.city":  "city est un champ obligatoire",
+			"bank_balance":  "bank_balance doit être supérieur ou égal à 0",
+		},
+		"nl": {
+			"contact_email": "contact_email moet een geldig e-mailadres zijn",
+			"name":          "name moet minimaal 3 tekens lang zijn",
+			"followers":     "followers moet 10 of kleiner zijn",
+			"tags":          "tags moet minimaal 1 items bevatten",
+			"Code":          "Code is ongeldig",
+			"address.city":  "city is een verplicht veld",
+			"bank_balance":  "bank_balance moet groter dan of gelijk aan 0 zijn",
+		},
+	}
+	for locale, want := range cases {
+		locale, want := locale, want
+		Convey("Given a validator with the "+locale+" translations, and an invalid struct", t, func() {
+			v, trans := translationsFor(locale)
+			Convey("When I validate it and translate the errors", func() {
+				s := invalidSupplier()
+				messages, err := sqljson.TranslateErrors(v.Struct(s), trans, s)
+				Convey("Then I should get translated messages keyed by JSON path", func() {
+					So(err, ShouldBeNil)
+					So(messages, ShouldResemble, want)
+				})
+			})
+		})
+	}
+	Convey("Given a validator with the en translations, and a NULL required field", t, func() {
+		v, trans := translationsFor("en")
+		s := invalidSupplier()
+		s.ContactEmail = sqljson.NullString{}
+		Convey("When I validate it and translate the errors", func() {
+			messages, err := sqljson.TranslateErrors(v.Struct(s), trans, s)
+			Convey("Then the field should be reported as required", func() {
+				So(err, ShouldBeNil)
+				So(messages["contact_email"], ShouldEqual, "contact_email is a required field")
+			})
+		})
+	})
+	Convey("Given a validator with RegisterNullTags and the fr translations, and structs failing cross-field tags", t, func() {
+		v, trans := translationsFor("fr")
+		So(sqljson.RegisterNullTags(v), ShouldBeNil)
+		start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+		deadline, _ := sqljson.ParseDate("2024-04-01")
+		period, _ := sqljson.ParseDate("2024-05-01")
+		Convey("When I validate them and translate the errors", func() {
+			b := &fieldsBooking{StartDate: sqljson.NewNullTime(start), EndDate: sqljson.NewNullTime(start)}
+			booking, errBooking := sqljson.TranslateErrors(v.Struct(b), trans, b)
+			c := &fieldsContact{}
+			contact, errContact := sqljson.TranslateErrors(v.Struct(c), trans, c)
+			e := fieldsEvent{Period: fieldsPeriod{Start: sqljson.NewNullDate(period)}, Deadline: sqljson.NewNullDate(deadline)}
+			event, errEvent := sqljson.TranslateErrors(v.Struct(&e), trans, e)
+			goNames, errGoNames := sqljson.TranslateErrors(v.Struct(b), trans, nil)
+			Convey("Then the fields named in params should be written by their JSON names", func() {
+				So(errBooking, ShouldBeNil)
+				So(booking["end_date"], ShouldEqual, "end_date doit être supérieur à start_date")
+				So(errContact, ShouldBeNil)
+				So(contact["email"], ShouldEqual, "un seul parmi email et phone doit être renseigné")
+				So(errEvent, ShouldBeNil)
+				So(event["deadline"], ShouldEqual, "deadline doit être supérieur à period.start")
+			})
+			Convey("And without the struct, they should keep their Go names", func() {
+				So(errGoNames, ShouldBeNil)
+				So(goNames["end_date"], ShouldEqual, "end_date doit être supérieur à StartDate")
+			})
+		})
+	})
+	Convey("Given a validator with RegisterNullTags and the en translations, and two structs of the same name", t, func() {
+		v, trans := translationsFor("en")
+		So(sqljson.RegisterNullTags(v), ShouldBeNil)
+		formA := func() interface{} {
+			type Form struct {
+				Start sqljson.NullInt64 `json:"start_a"`
+				End   sqljson.NullInt64 `json:"end" validate:"null_gtfield=Start"`
+			}
+			return &Form{Start: sqljson.NewNullInt64(2), End: sqljson.NewNullInt64(1)}
+		}()
+		formB := func() interface{} {
+			type Form struct {
+				Start sqljson.NullInt64 `json:"start_b"`
+				End   sqljson.NullInt64 `json:"end" validate:"null_gtfield=Start"`
+			}
+			return &Form{Start: sqljson.NewNullInt64(2), End: sqljson.NewNullInt64(1)}
+		}()
+		Convey("When I validate both and translate the errors", func() {
+			messagesA, errA := sqljson.TranslateErrors(v.Struct(formA), trans, formA)
+			messagesB, errB := sqljson.TranslateErrors(v.Struct(formB), trans, formB)
+			Convey("Then each message should name the field of its own struct", func() {
+				So(errA, ShouldBeNil)
+				So(messagesA["end"], ShouldEqual, "end must be greater than start_a")
+				So(errB, ShouldBeNil)
+				So(messagesB["end"], ShouldEqual, "end must be greater than start_b")
+			})
+		})
+	})
+	Convey("Given a nil error and an error which is not a validation error", t, func() {
+		_, trans := translationsFor("en")
+		other := errors.New("boom")
+		Convey("When I translate them", func() {
+			messagesNil, errNil := sqljson.TranslateErrors(nil, trans, nil)
+			messagesOther, errOther := sqljson.TranslateErrors(other, trans, nil)
+			Convey("Then I should get nothing, and the error as-is", func() {
+				So(messagesNil, ShouldBeNil)
+				So(errNil, ShouldBeNil)
+				So(messagesOther, ShouldBeNil)
+				So(errOther, ShouldEqual, other)
+			})
+		})
+	})
+	Convey("Given a translator for a locale without messages", t, func() {
+		trans, _ := ut.New(en_CA.New(), en_CA.New()).GetTranslator("en_CA")
+		Convey("When I register its translations", func() {
+			err := sqljson.RegisterTranslations(sqljson.NewValidator(), trans)
+			Convey("Then I should get an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, `no validation messages for locale "en_CA"`)
+			})
+		})
+	})
+}
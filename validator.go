@@ -0,0 +1,125 @@
+package sqljson
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+// validatorTypes are the sqljson types registered by RegisterValidator. Every
+// new type in the package is added here, along with the common
+// instantiations of the generic types.
+var validatorTypes = []interface{}{
+	NullString{},
+	NullBool{},
+	NullInt64{},
+	NullFloat64{},
+	NullTime{},
+	NullInt32{},
+	NullInt16{},
+	NullByte{},
+	NullUint64{},
+	EmptyNullString{},
+	BlankNullString{},
+	NullRawMessage{},
+	NullDecimal{},
+	NullUUID{},
+	NullBytes{},
+	NullDate{},
+	NullTimeOfDay{},
+	NullDuration{},
+	LenientNullBool{},
+	LenientNullInt64{},
+	LenientNullFloat64{},
+	Null[string]{},
+	Null[bool]{},
+	Null[int]{},
+	Null[int16]{},
+	Null[int32]{},
+	Null[byte]{},
+	Null[int64]{},
+	Null[uint64]{},
+	Null[float64]{},
+	Null[time.Time]{},
+	Optional[string]{},
+	Optional[bool]{},
+	Optional[int]{},
+	Optional[int16]{},
+	Optional[int32]{},
+	Optional[byte]{},
+	Optional[int64]{},
+	Optional[uint64]{},
+	Optional[float64]{},
+	Optional[time.Time]{},
+	NullJSON[map[string]interface{}]{},
+}
+
+// allValidatorTypes returns validatorTypes, a NullEnum of every registered
+// enum type, and types.
+func allValidatorTypes(types []interface{}) []interface{} {
+	all := append([]interface{}{}, validatorTypes...)
+	all = append(all, enumValidatorTypes()...)
+	return append(all, types...)
+}
+
+// RegisterValidator registers ValidateValuer with v for every sqljson type,
+// including NullEnum of every type registered with RegisterEnum, plus any
+// other types given, such as further instantiations of Null or Optional. It
+// also registers the oneof tag, which this version of validator lacks, and
+// the date_min, date_max, time_min, time_max, duration_min and duration_max
+// tags.
+func RegisterValidator(v *validator.Validate, types ...interface{}) error {
+	v.RegisterCustomTypeFunc(ValidateValuer, allValidatorTypes(types)...)
+	err := v.RegisterValidation("oneof", validateOneOf)
+	if err != nil {
+		return err
+	}
+	for tag, fn := range dateValidations {
+		err = v.RegisterValidation(tag, fn)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateOneOf is the oneof validation: the field, formatted as text, is
+// one of the values of the param, separated by spaces. NULL is none of them.
+func validateOneOf(fl validator.FieldLevel) bool {
+	if isNullField(fl) {
+		return false
+	}
+	value := fmt.Sprint(fl.Field().Interface())
+	for _, allowed := range strings.Fields(fl.Param()) {
+		if value == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// NewValidator returns a validator with every sqljson type registered, which
+// reports fields by their JSON names. It panics if RegisterValidator fails,
+// which only an invalid tag of this package can cause.
+func NewValidator() *validator.Validate {
+	v := validator.New()
+	err := RegisterValidator(v)
+	if err != nil {
+		panic(err)
+	}
+	v.RegisterTagNameFunc(jsonTagName)
+	return v
+}
+
+// jsonTagName returns the name of a field in its json tag, or an empty string
+// to leave the Go field name in place.
+func jsonTagName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,86 @@
+package sqljson_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/rhaseven7h/sqljson"
+
+	validator "gopkg.in/go-playground/validator.v9"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRegisterValidator(t *testing.T) {
+	type validatorStruct struct {
+		ContactEmail sqljson.NullString       `json:"contact_email" validate:"required,email"`
+		IsAdmin      sqljson.NullBool         `json:"is_admin" validate:"required"`
+		Followers    sqljson.NullInt64        `json:"followers" validate:"required,min=1000"`
+		BankBalance  sqljson.NullFloat64      `json:"bank_balance" validate:"required,max=555.55"`
+		CreatedAt    sqljson.NullTime         `json:"created_at" validate:"required"`
+		Nickname     sqljson.Optional[string] `json:"nickname" validate:"omitempty,min=3"`
+		Level        sqljson.Null[uint8]      `json:"level" validate:"omitempty,max=10"`
+	}
+	invalid := &validatorStruct{
+		ContactEmail: sqljson.NullString{NullString: sql.NullString{String: "not an email", Valid: true}},
+		IsAdmin:      sqljson.NullBool{NullBool: sql.NullBool{Bool: false, Valid: true}},
+		Followers:    sqljson.NullInt64{NullInt64: sql.NullInt64{Int64: 999, Valid: true}},
+		BankBalance:  sqljson.NullFloat64{NullFloat64: sql.NullFloat64{Float64: 555.56, Valid: true}},
+		Nickname:     sqljson.Optional[string]{Null: sqljson.Null[string]{Null: sql.Null[string]{V: "ab", Valid: true}}},
+		Level:        sqljson.Null[uint8]{Null: sql.Null[uint8]{V: 11, Valid: true}},
+	}
+
+	Convey("Given a validator with RegisterValidator and an extra type", t, func() {
+		validate := validator.New()
+		So(sqljson.RegisterValidator(validate, sqljson.Null[uint8]{}), ShouldBeNil)
+		Convey("When I validate a struct with invalid values", func() {
+			err := validate.Struct(invalid)
+			Convey("Then I should get errors for every sqljson field", func() {
+				So(err, ShouldNotBeNil)
+				validationErrors := err.(validator.ValidationErrors)
+				So(len(validationErrors), ShouldEqual, 7)
+				So(validationErrors[0].Field(), ShouldEqual, "ContactEmail")
+				So(validationErrors[0].Tag(), ShouldEqual, "email")
+				So(validationErrors[1].Tag(), ShouldEqual, "required")
+				So(validationErrors[2].Tag(), ShouldEqual, "min")
+				So(validationErrors[3].Tag(), ShouldEqual, "max")
+				So(validationErrors[4].Field(), ShouldEqual, "CreatedAt")
+				So(validationErrors[4].Tag(), ShouldEqual, "required")
+				So(validationErrors[5].Field(), ShouldEqual, "Nickname")
+				So(validationErrors[5].Tag(), ShouldEqual, "min")
+				So(validationErrors[6].Field(), ShouldEqual, "Level")
+				So(validationErrors[6].Tag(), ShouldEqual, "max")
+			})
+		})
+	})
+	Convey("Given a validator from NewValidator", t, func() {
+		validate := sqljson.NewValidator()
+		Convey("When I validate a struct with invalid values", func() {
+			err := validate.Struct(&struct {
+				ContactEmail sqljson.NullString `json:"contact_email,omitempty" validate:"required,email"`
+				CreatedAt    sqljson.NullTime   `json:"-" validate:"required"`
+			}{
+				ContactEmail: sqljson.NullString{NullString: sql.NullString{String: "not an email", Valid: true}},
+			})
+			Convey("Then I should get errors named after the JSON fields", func() {
+				So(err, ShouldNotBeNil)
+				validationErrors := err.(validator.ValidationErrors)
+				So(len(validationErrors), ShouldEqual, 2)
+				So(validationErrors[0].Field(), ShouldEqual, "contact_email")
+				So(validationErrors[0].StructField(), ShouldEqual, "ContactEmail")
+				So(validationErrors[1].Field(), ShouldEqual, "CreatedAt")
+			})
+		})
+		Convey("When I validate a struct with valid values", func() {
+			err := validate.Struct(&struct {
+				CreatedAt sqljson.NullTime `json:"created_at" validate:"required"`
+			}{
+				CreatedAt: sqljson.NullTime{NullTime: sql.NullTime{Time: time.Now(), Valid: true}},
+			})
+			Convey("Then I should get no errors", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}